@@ -0,0 +1,173 @@
+// Under the Apache-2.0 License
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/sog"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+// ExplainCommand is the command line argument that selects the explain mode.
+const ExplainCommand = "explain"
+
+// RunExplain runs the engine to completion, then writes the full evaluation of every rule and group for each object with the id.
+//
+// Returns false if no object has the id.
+func RunExplain(
+	cfg *config.ProjectConfig,
+	data *ingest.AllData,
+	objectId string,
+	out io.Writer,
+	ctx context.Context,
+) (bool, error) {
+	engine := runner.New(data, cfg)
+	state, pReader := engine.Start(ctx)
+	for state.Step() {
+	}
+	state.Stop()
+	pReader.Read(ctx)
+
+	all := state.Objects()
+	checks := state.Results().Checks()
+	found := false
+	errs := make([]error, 0)
+	for _, o := range all {
+		if o.Id != objectId {
+			continue
+		}
+		found = true
+		errs = append(errs, ExplainObject(o, data.RuleSets, state.Candidates, checks, out))
+	}
+	return found, errors.Join(errs...)
+}
+
+// ExplainObject writes the evaluation tree of each rule and group against the object.
+//
+// The candidates return the objects each group builds its SOG instances from.
+func ExplainObject(
+	o *obj.EngineObj,
+	rules *srule.RuleSet,
+	candidates func(group *srule.Group) []*obj.EngineObj,
+	checks []runner.Check,
+	out io.Writer,
+) error {
+	w := &explainWriter{out: out}
+	w.line("", "Object %s", o.String())
+	for _, r := range rules.Rules {
+		w.line("  ", "Rule %s", r.Id)
+		m := matcher.Explain(o, r.Matchers)
+		w.tree("    ", "Matchers:", m)
+		if !m.Matched {
+			// The object doesn't need to conform.
+			continue
+		}
+		for _, c := range r.Conformities {
+			w.tree("    ", fmt.Sprintf("Conformity (%s):", c.Level), matcher.Explain(o, c.Matchers))
+		}
+		for _, c := range checks {
+			if c.RuleId == r.Id && c.ObjectId == o.Id && c.Kind == runner.CoverageCheck {
+				w.check("    ", c)
+			}
+		}
+	}
+	for _, g := range rules.Groups {
+		w.line("  ", "Group %s", g.Id)
+		if o.Source.Construct != nil && *o.Source.Construct == g.Id {
+			// The object is one of the group's SOGs, so it must conform.
+			for _, c := range g.Conformities {
+				w.tree("    ", fmt.Sprintf("Conformity (%s):", c.Level), matcher.Explain(o, c.Matchers))
+			}
+		}
+		j := sog.ExplainJoin(g, o, candidates(g))
+		w.tree("    ", "Matchers:", j.Matchers)
+		if !j.Matchers.Matched {
+			continue
+		}
+		w.line("    ", "Shared values: %s", sharedText(g.KeySharedValues, j.Shared))
+		if len(j.Peers) > 0 {
+			w.line("    ", "Joins with: %s", objIds(j.Peers))
+		} else {
+			w.line("    ", "Joins with: no other object shares the values")
+		}
+		for _, n := range j.Near {
+			w.line(
+				"    ",
+				"Kept apart from %s by %s (has %s)",
+				objIds(n.Members),
+				strings.Join(n.Mismatched, ", "),
+				sharedText(n.Mismatched, n.Shared),
+			)
+		}
+		for _, c := range checks {
+			if c.GroupId == g.Id && c.ObjectId == o.Id {
+				w.check("    ", c)
+			}
+		}
+	}
+	return w.err
+}
+
+// explainWriter keeps the first write error, so the explanation reads as a simple list of lines.
+type explainWriter struct {
+	out io.Writer
+	err error
+}
+
+func (w *explainWriter) line(prefix string, format string, args ...any) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.out, prefix+format+"\n", args...)
+	}
+}
+
+func (w *explainWriter) tree(prefix string, title string, e *matcher.Explanation) {
+	w.line(prefix, "%s", title)
+	if w.err == nil {
+		w.err = e.Write(w.out, prefix+"  ")
+	}
+}
+
+func (w *explainWriter) check(prefix string, c runner.Check) {
+	name := c.Kind
+	if c.Key != "" {
+		name += " " + c.Key
+	}
+	if c.Passed() {
+		w.line(prefix, "[pass] %s", name)
+		return
+	}
+	w.line(prefix, "[fail] %s: %s", name, strings.Join(c.Failures, "; "))
+}
+
+func sharedText(keys []string, shared map[string]obj.DescriptorValues) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		v := shared[k]
+		vals := make([]string, 0, v.Count())
+		for _, n := range v.Number {
+			vals = append(vals, fmt.Sprintf("%g", n))
+		}
+		for _, t := range v.Text {
+			vals = append(vals, "'"+t+"'")
+		}
+		parts[i] = k + "=(" + strings.Join(vals, ", ") + ")"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func objIds(objs []*obj.EngineObj) string {
+	ids := make([]string, len(objs))
+	for i, o := range objs {
+		ids[i] = o.Id
+	}
+	return strings.Join(ids, ", ")
+}
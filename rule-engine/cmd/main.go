@@ -6,28 +6,65 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/groboclown/qazaar-testing/rule-engine/config"
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/report"
 	"github.com/groboclown/qazaar-testing/rule-engine/validate"
 )
 
 var (
-	configFile string
-	reportDir  string
+	configFile  string
+	reportDir   string
+	formats     string
+	objectId    string
+	parallelism int
+	variables   = make(varFlags)
 )
 
 func init() {
 	flag.StringVar(&configFile, "config-file", "", "Configuration file location")
 	flag.StringVar(&reportDir, "report-dir", "", "Generated report directory")
+	flag.StringVar(&formats, "report-format", report.JsonFormat, "Comma separated report formats to write into the report directory ("+strings.Join(report.FormatNames(), ", ")+")")
+	flag.IntVar(&parallelism, "parallelism", 0, "Maximum number of concurrent validation and rule checking workers; defaults to the number of CPUs")
+	flag.StringVar(&objectId, "object", "", "Object id to explain, for the '"+ExplainCommand+"' command")
+	flag.Var(variables, "var", "Rule variable value as 'name=value'; may be repeated, and overrides the configuration")
+}
+
+// varFlags collects the repeated 'name=value' variable arguments.
+type varFlags map[string]string
+
+func (v varFlags) String() string {
+	parts := make([]string, 0, len(v))
+	for k, x := range v {
+		parts = append(parts, k+"="+x)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (v varFlags) Set(s string) error {
+	k, x, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("variable must have the form 'name=value' (%s)", s)
+	}
+	v[k] = x
+	return nil
 }
 
 func main() {
 	// This ships general error messages to stderr,
 	// and the informational problems to stdout (what the end user cares about).
-	flag.Parse()
+	explain := len(os.Args) > 1 && os.Args[1] == ExplainCommand
+	if explain {
+		flag.CommandLine.Parse(os.Args[2:])
+	} else {
+		flag.Parse()
+	}
 
 	if configFile == "" {
 		fmt.Fprintln(os.Stderr, "Error: must set 'config-file' value.")
@@ -38,23 +75,85 @@ func main() {
 		fmt.Printf("Error reading config file '%s': %s", configFile, err.Error())
 		os.Exit(1)
 	}
+	if pc.Variables == nil {
+		pc.Variables = make(map[string]any)
+	}
+	for k, v := range variables {
+		pc.Variables[k] = v
+	}
 
-	ctx := context.Background()
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := pool.With(runCtx, pool.New(runCtx, parallelism))
+	if explain {
+		mainExplain(pc, ctx)
+		return
+	}
+	rep := NewReport(pc, flag.Args())
 	data, validationProbs := ReadValidate(pc, flag.Args(), ctx)
 	ReportProblems(validationProbs, os.Stdout)
+	rep.Add(report.ValidationPhase, validationProbs)
 	if validationProbs.HasErrors() {
+		WriteReport(rep)
 		fmt.Fprintf(os.Stderr, "Loading data encountered unrecoverable problems.")
 		os.Exit(1)
 	}
 
-	engineProbs := RunEngine(pc, data, ctx)
+	engineProbs, results := RunEngine(pc, data, ctx)
 	ReportProblems(engineProbs, os.Stdout)
+	rep.Add(report.EnginePhase, engineProbs)
+	rep.AddChecks(results)
+	WriteReport(rep)
+	if h := results.Halted(); h != "" {
+		fmt.Fprintf(os.Stderr, "Halted the rule evaluation early: %s\n", h)
+	}
 	if engineProbs.HasErrors() {
 		fmt.Fprintf(os.Stderr, "Documents have rule conformity issues.")
 		os.Exit(1)
 	}
+}
+
+// mainExplain runs the explain command, which prints how each rule and group evaluates the object.
+func mainExplain(pc *config.ProjectConfig, ctx context.Context) {
+	if objectId == "" {
+		fmt.Fprintln(os.Stderr, "Error: must set 'object' value for the explain command.")
+		os.Exit(1)
+	}
+	data, validationProbs := ReadValidate(pc, flag.Args(), ctx)
+	if validationProbs.HasErrors() {
+		ReportProblems(validationProbs, os.Stdout)
+		fmt.Fprintf(os.Stderr, "Loading data encountered unrecoverable problems.")
+		os.Exit(1)
+	}
+	found, err := RunExplain(pc, data, objectId, os.Stdout, ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing the explanation: %s\n", err.Error())
+		os.Exit(1)
+	}
+	if !found {
+		fmt.Fprintf(os.Stderr, "No object with id '%s'.\n", objectId)
+		os.Exit(1)
+	}
+}
+
+// NewReport creates the run report with the manifest of input files.
+func NewReport(cfg *config.ProjectConfig, docFiles []string) *report.Report {
+	manifest, err := ingest.FindManifest(cfg, docFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding input files: %s\n", err.Error())
+	}
+	return report.New(manifest, time.Now())
+}
 
-	// Note: this should also create report files in the report directory.
+// WriteReport writes the report files into the report directory, if one was given.
+func WriteReport(rep *report.Report) {
+	if reportDir == "" {
+		return
+	}
+	if err := rep.Write(reportDir, strings.Split(formats, ",")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing the report to '%s': %s\n", reportDir, err.Error())
+		os.Exit(1)
+	}
 }
 
 func ReadValidate(
@@ -69,6 +168,7 @@ func ReadValidate(
 
 	probGen.Complete()
 	probs := probRead.Read(ctx)
+	probs.Merge(data.Problems())
 	return data, probs
 }
 
@@ -76,11 +176,11 @@ func RunEngine(
 	cfg *config.ProjectConfig,
 	data *ingest.AllData,
 	ctx context.Context,
-) *problem.ProblemSet {
+) (*problem.ProblemSet, *runner.Results) {
 	engine := runner.New(data, cfg)
 	state, pReader := engine.Start(ctx)
 	for state.Step() {
 	}
 	state.Stop()
-	return pReader.Read(ctx)
+	return pReader.Read(ctx), state.Results()
 }
@@ -31,7 +31,7 @@ func ReportProblems(probs *problem.ProblemSet, out io.Writer) error {
 		_, e := fmt.Fprint(out, "Quiet:\n")
 		errs = append(errs, e)
 		for _, p := range quiet {
-			_, e := fmt.Fprintf(out, "  %s\n", p.String())
+			_, e := fmt.Fprintf(out, "  %s\n", problemLine(p))
 			errs = append(errs, e)
 		}
 	}
@@ -39,7 +39,7 @@ func ReportProblems(probs *problem.ProblemSet, out io.Writer) error {
 		_, e := fmt.Fprint(out, "Informative:\n")
 		errs = append(errs, e)
 		for _, p := range info {
-			_, e := fmt.Fprintf(out, "  %s\n", p.String())
+			_, e := fmt.Fprintf(out, "  %s\n", problemLine(p))
 			errs = append(errs, e)
 		}
 	}
@@ -47,7 +47,7 @@ func ReportProblems(probs *problem.ProblemSet, out io.Writer) error {
 		_, e := fmt.Fprint(out, "Warnings:\n")
 		errs = append(errs, e)
 		for _, p := range warn {
-			_, e := fmt.Fprintf(out, "  %s\n", p.String())
+			_, e := fmt.Fprintf(out, "  %s\n", problemLine(p))
 			errs = append(errs, e)
 		}
 	}
@@ -55,10 +55,18 @@ func ReportProblems(probs *problem.ProblemSet, out io.Writer) error {
 		_, e := fmt.Fprint(out, "Errors:\n")
 		errs = append(errs, e)
 		for _, p := range err {
-			_, e := fmt.Fprintf(out, "  %s\n", p.String())
+			_, e := fmt.Fprintf(out, "  %s\n", problemLine(p))
 			errs = append(errs, e)
 		}
 	}
 
 	return errors.Join(errs...)
 }
+
+// problemLine formats the problem with the input file location, if known.
+func problemLine(p problem.Problem) string {
+	if p.Origin.IsZero() {
+		return p.String()
+	}
+	return p.String() + "\n    at " + p.Origin.String()
+}
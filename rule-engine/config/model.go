@@ -5,13 +5,30 @@ import "github.com/groboclown/qazaar-testing/rule-engine/problem"
 
 // ProjectConfig defines a project setup for processing the rules.
 type ProjectConfig struct {
-	LevelMap      map[string]int `json:"level-map"` // Maps between level names and a error level; 0 is lowest
-	InfoLevel     int            `json:"info"`      // Level at or above for informative issues.
-	WarningLevel  int            `json:"warn"`      // Level at or above for warnings.
-	ErrorLevel    int            `json:"error"`     // Level at or above for errors.
-	RefDirs       []string       `json:"ref-dir"`   // Base directory for finding the rule and ontology files
-	RuleFiles     []string       `json:"rules"`     // Glob pattern for rule files under the ref dirs
-	OntologyFiles []string       `json:"ontology"`  // Glob pattern for ontology files under the ref dirs
+	LevelMap         map[string]int    `json:"level-map"`          // Maps between level names and a error level; 0 is lowest
+	InfoLevel        int               `json:"info"`               // Level at or above for informative issues.
+	WarningLevel     int               `json:"warn"`               // Level at or above for warnings.
+	ErrorLevel       int               `json:"error"`              // Level at or above for errors.
+	RefDirs          []string          `json:"ref-dir"`            // Base directory for finding the rule and ontology files
+	RuleFiles        []string          `json:"rules"`              // Glob pattern for rule files under the ref dirs
+	OntologyFiles    []string          `json:"ontology"`           // Glob pattern for ontology files under the ref dirs
+	TransformFiles   []string          `json:"transforms"`         // Glob pattern for ontology transform files under the ref dirs; applied in order
+	VariableFiles    []string          `json:"variables"`          // Glob pattern for rule variable files under the ref dirs
+	TestExecFiles    []string          `json:"test-executions"`    // Glob pattern for test execution result files under the ref dirs
+	Variables        map[string]any    `json:"var"`                // Rule variable values; these override the variable files
+	Halt             *HaltPolicy       `json:"halt"`               // When to stop evaluating the rules early; nil runs to completion
+	Formats          map[string]string `json:"formats"`            // Named value formats as regular expressions; these override the built-in formats
+	StrictSourceRefs bool              `json:"strict-source-refs"` // Report a source ref missing from the commonSourceRefs as an error, rather than a warning
+}
+
+// HaltPolicy stops the engine once enough implications fail.
+//
+// Each limit is independent; the engine halts as soon as any of them is reached.
+// Zero or missing limits never halt.
+type HaltPolicy struct {
+	MaxErrors int            `json:"max-errors"` // Halt after this many failed checks at the error level.
+	MaxLevels map[string]int `json:"max-levels"` // Halt after this many failed implications with the rule level name.
+	Rules     []string       `json:"rules"`      // Halt as soon as any of these rule or group ids fails a check.
 }
 
 // RuntimeConfig contains shared data for processing the rules.
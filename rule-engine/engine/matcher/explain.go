@@ -0,0 +1,129 @@
+// Under the Apache-2.0 License
+package matcher
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+// Explanation is the full evaluation tree of a matcher against an object.
+//
+// Unlike IsMatch, this evaluates every node without exiting early, so that each
+// node's result is known.  The top level result is the same as IsMatch.
+type Explanation struct {
+	Operation string
+	Contains  *srule.ContainsMatcher
+	Unique    *srule.UniqueMatcher
+	Values    obj.DescriptorValues // The object values the contains node checked.
+	Matched   bool
+	Children  []*Explanation
+}
+
+// Explain evaluates the matcher against the object, keeping the result of each node.
+func Explain(o *obj.EngineObj, matcher *srule.MatchingDescriptorSet) *Explanation {
+	return explainCollection(o, srule.AndCollection, matcher)
+}
+
+func explainCollection(
+	o *obj.EngineObj,
+	operation srule.CollectionOperation,
+	matcher *srule.MatchingDescriptorSet,
+) *Explanation {
+	ret := &Explanation{Operation: collectionName(operation), Children: make([]*Explanation, 0)}
+	if matcher == nil || o == nil {
+		return ret
+	}
+	for _, c := range matcher.Collection {
+		ret.Children = append(ret.Children, explainCollection(o, c.Operation, c.Matchers))
+	}
+	for _, c := range matcher.Contains {
+		ret.Children = append(ret.Children, explainContains(o, &c))
+	}
+	for _, u := range matcher.Unique {
+		ok, _ := IsUniqueMatch(o, &u)
+		ret.Children = append(ret.Children, &Explanation{
+			Operation: "unique",
+			Unique:    &u,
+			Matched:   ok,
+		})
+	}
+
+	// Matches the IsCollectionMatch logic.
+	switch operation {
+	case srule.OrCollection:
+		for _, c := range ret.Children {
+			ret.Matched = ret.Matched || c.Matched
+		}
+	default:
+		ret.Matched = true
+		for _, c := range ret.Children {
+			ret.Matched = ret.Matched && c.Matched
+		}
+		if operation == srule.NotCollection {
+			ret.Matched = !ret.Matched
+		}
+	}
+	return ret
+}
+
+func explainContains(o *obj.EngineObj, contains *srule.ContainsMatcher) *Explanation {
+	ok, _ := IsContainsMatch(o, contains)
+	return &Explanation{
+		Operation: "contains",
+		Contains:  contains,
+		Values:    containsValue(o, contains),
+		Matched:   ok,
+	}
+}
+
+// Write writes the explanation tree, one node per line, with each level indented under the prefix.
+func (e *Explanation) Write(w io.Writer, prefix string) error {
+	if e == nil {
+		return nil
+	}
+	line := e.Operation
+	if e.Contains != nil {
+		line = describeContains(e.Contains) + "; checked (" + describeValues(e.Values) + ")"
+	}
+	if e.Unique != nil {
+		line = describeUnique(e.Unique)
+	}
+	if _, err := fmt.Fprintf(w, "%s[%s] %s\n", prefix, passFail(e.Matched), line); err != nil {
+		return err
+	}
+	for _, c := range e.Children {
+		if err := c.Write(w, prefix+"  "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Explanation) String() string {
+	var b strings.Builder
+	_ = e.Write(&b, "")
+	return b.String()
+}
+
+func collectionName(operation srule.CollectionOperation) string {
+	switch operation {
+	case srule.AndCollection:
+		return "AND"
+	case srule.OrCollection:
+		return "OR"
+	case srule.NotCollection:
+		return "NOT"
+	}
+	return "<UNSUPPORTED>"
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
@@ -0,0 +1,56 @@
+// Under the Apache-2.0 License
+package matcher_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+func Test_Explain(t *testing.T) {
+	ob := obj.NewObjFactory(nil).Empty(obj.ObjSource{})
+	o := ob.Seal()
+	inRange := func(min, max float64) srule.ContainsMatcher {
+		return srule.ContainsMatcher{
+			Operation: srule.ContainsSome,
+			Count:     true,
+			Key:       "e1",
+			Checks:    srule.ValueCheckSet{Numeric: []srule.NumericBoundsCheck{{Min: min, Max: max}}},
+		}
+	}
+	m := &srule.MatchingDescriptorSet{
+		Collection: []srule.CollectionMatcher{
+			{Operation: srule.OrCollection, Matchers: &srule.MatchingDescriptorSet{
+				Contains: []srule.ContainsMatcher{inRange(5, 6), inRange(0, 0)},
+			}},
+			{Operation: srule.NotCollection, Matchers: &srule.MatchingDescriptorSet{
+				Contains: []srule.ContainsMatcher{inRange(3, 4)},
+			}},
+		},
+		Contains: []srule.ContainsMatcher{inRange(1, 2)},
+	}
+
+	e := matcher.Explain(o, m)
+	ok, errs := matcher.IsMatch(o, m)
+	if e.Matched != ok {
+		t.Errorf("explanation %v disagrees with the match %v", e.Matched, ok)
+	}
+	expected := `[fail] AND
+  [pass] OR
+    [fail] count of e1 contains some range [5.000000, 6.000000]; checked (0.0000)
+    [pass] count of e1 contains some range [0.000000, 0.000000]; checked (0.0000)
+  [pass] NOT
+    [fail] count of e1 contains some range [3.000000, 4.000000]; checked (0.0000)
+  [fail] count of e1 contains some range [1.000000, 2.000000]; checked (0.0000)
+`
+	if diff := cmp.Diff(expected, e.String()); diff != "" {
+		t.Errorf("explanation mismatch (-want +got):\n%s", diff)
+	}
+	if len(errs) != 2 {
+		// The failed OR member must remain along with the final failure.
+		t.Errorf("expected the OR and contains mismatches, found %v", errs)
+	}
+}
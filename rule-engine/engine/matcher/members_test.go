@@ -0,0 +1,106 @@
+// Under the Apache-2.0 License
+package matcher_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+func Test_MemberMatchers(t *testing.T) {
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "free", "key": "op", "caseSensitive": true, "maximumCount": 10}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	descriptors.Add(sont.MemberOntology())
+	if descriptors.Problems.HasProblems() {
+		t.Fatal(descriptors.Problems.Problems())
+	}
+	factory := obj.NewObjFactory(descriptors)
+	mk := func(id string, ops ...string) *obj.EngineObj {
+		ob := factory.Empty(obj.ObjSource{})
+		ob.Add("op", obj.DescriptorValues{Text: ops})
+		o := ob.Seal()
+		o.Id = id
+		return o
+	}
+	unique := factory.FromGroup([]*obj.EngineObj{mk("a", "get"), mk("b", "put", "put"), mk("c")}, "g", "g(unique)")
+	shared := factory.FromGroup([]*obj.EngineObj{mk("a", "get"), mk("b", "get", "put"), mk("c", "put")}, "g", "g(shared)")
+	single := mk("s", "get", "get")
+
+	text := func(t ...string) srule.ValueCheckSet {
+		ret := srule.ValueCheckSet{}
+		for _, v := range t {
+			ret.Text = append(ret.Text, srule.StringCheck{R: regexp.MustCompile("^" + v + "$")})
+		}
+		return ret
+	}
+	count := func(n float64) srule.ValueCheckSet {
+		return srule.ValueCheckSet{Numeric: []srule.NumericBoundsCheck{{Min: n, Max: n}}}
+	}
+
+	tests := []struct {
+		name     string
+		o        *obj.EngineObj
+		matchers srule.MatchingDescriptorSet
+		expected bool
+	}{
+		{"member-count", unique, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsExactly, Key: sont.MemberCountKey, Checks: count(3)},
+		}}, true},
+		{"member-ids", unique, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsExactly, Key: sont.MemberIdsKey, Checks: text("a", "b", "c")},
+		}}, true},
+		{"union", shared, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsExactly, Members: srule.UnionMembers, Key: "op", Checks: text("get", "put")},
+		}}, true},
+		{"union-count", shared, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsExactly, Members: srule.UnionMembers, Count: true, Key: "op", Checks: count(2)},
+		}}, true},
+		{"flat-count", shared, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsExactly, Count: true, Key: "op", Checks: count(4)},
+		}}, true},
+		{"each-fail", unique, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsSome, Members: srule.EachMember, Key: "op", Checks: text("get", "put")},
+		}}, false},
+		{"each-pass", shared, srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+			{Operation: srule.ContainsSome, Members: srule.EachMember, Key: "op", Checks: text("get", "put")},
+		}}, true},
+		{"unique-pass", unique, srule.MatchingDescriptorSet{Unique: []srule.UniqueMatcher{{Key: "op"}}}, true},
+		{"unique-fail", shared, srule.MatchingDescriptorSet{Unique: []srule.UniqueMatcher{{Key: "op"}}}, false},
+		{"unique-single", single, srule.MatchingDescriptorSet{Unique: []srule.UniqueMatcher{{Key: "op"}}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, errs := matcher.IsMatch(test.o, &test.matchers)
+			if ok != test.expected {
+				t.Errorf("expected %v, found %v (%v)", test.expected, ok, errs)
+			}
+			if e := matcher.Explain(test.o, &test.matchers); e.Matched != ok {
+				t.Errorf("explanation %v disagrees with the match %v", e.Matched, ok)
+			}
+		})
+	}
+
+	_, errs := matcher.IsMatch(shared, &srule.MatchingDescriptorSet{Unique: []srule.UniqueMatcher{{Key: "op"}}})
+	if len(errs) != 1 {
+		t.Fatalf("expected one mismatch, found %v", errs)
+	}
+	msg := errs[0].String()
+	if !strings.HasSuffix(msg, "members have unique op but 'get' is shared by a, b; 'put' is shared by b, c") {
+		t.Errorf("unexpected message %s", msg)
+	}
+}
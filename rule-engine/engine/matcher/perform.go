@@ -63,7 +63,15 @@ func IsCollectionMatch(
 		res, errs := IsContainsMatch(obj, &c)
 		rErrs = append(rErrs, errs...)
 		if earlyExitCondition == res {
-			return earlyExitCondition, errs
+			return earlyExitCondition, rErrs
+		}
+	}
+
+	for _, u := range matcher.Unique {
+		res, errs := IsUniqueMatch(obj, &u)
+		rErrs = append(rErrs, errs...)
+		if earlyExitCondition == res {
+			return earlyExitCondition, rErrs
 		}
 	}
 	if !earlyExitCondition && len(rErrs) <= 0 {
@@ -88,15 +96,24 @@ func IsContainsMatch(
 		return false, []MatcherMismatch{}
 	}
 
-	val, distinct := obj.Value(contains.Key)
-	if contains.Distinct && !distinct {
-		// Only perform the extra make-it-distinct logic if the key isn't already distinct.
-		val = val.Distinct()
-	}
-	if contains.Count {
-		val = val.CountValue()
+	if contains.Members == srule.EachMember {
+		// Every member must match the same check on its own values.
+		each := *contains
+		each.Members = srule.ObjectValues
+		for _, m := range obj.Members() {
+			if ok, _ := IsContainsMatch(m, &each); !ok {
+				return false, []MatcherMismatch{{
+					Obj: obj,
+					Contains: &MismatchContains{
+						Contains: contains,
+					},
+				}}
+			}
+		}
+		return true, nil
 	}
 
+	val := containsValue(obj, contains)
 	if listMatch(contains.Operation, val.Number, contains.Checks.Numeric, numericCheckConst) {
 		return true, nil
 	}
@@ -112,6 +129,91 @@ func IsContainsMatch(
 	}}
 }
 
+// containsValue returns the object values the contains matcher checks.
+//
+// For each member checks, this is every member's values joined together.
+func containsValue(o *obj.EngineObj, contains *srule.ContainsMatcher) obj.DescriptorValues {
+	var val obj.DescriptorValues
+	distinct := false
+	switch contains.Members {
+	case srule.UnionMembers:
+		val = o.UnionValue(contains.Key)
+		distinct = true
+	case srule.EachMember:
+		for _, v := range o.MemberValues(contains.Key) {
+			val.Number = append(val.Number, v.Number...)
+			val.Text = append(val.Text, v.Text...)
+		}
+		return val
+	default:
+		val, distinct = o.Value(contains.Key)
+	}
+	if contains.Distinct && !distinct {
+		// Only perform the extra make-it-distinct logic if the key isn't already distinct.
+		val = val.Distinct()
+	}
+	if contains.Count {
+		val = val.CountValue()
+	}
+	return val
+}
+
+// IsUniqueMatch checks that no two members of the object share a value for the key.
+func IsUniqueMatch(
+	obj *obj.EngineObj,
+	unique *srule.UniqueMatcher,
+) (bool, []MatcherMismatch) {
+	if unique == nil || obj == nil {
+		return false, []MatcherMismatch{}
+	}
+	if len(sharedMemberValues(obj, unique.Key)) <= 0 {
+		return true, nil
+	}
+	return false, []MatcherMismatch{{
+		Obj: obj,
+		Unique: &MismatchUnique{
+			Unique: unique,
+		},
+	}}
+}
+
+// sharedMemberValue is one value found in more than one member.
+type sharedMemberValue struct {
+	Value   string
+	Members []*obj.EngineObj
+}
+
+// sharedMemberValues returns the values of the key that more than one member has, in the order first found.
+//
+// A member with the same value more than once does not conflict with itself.
+func sharedMemberValues(o *obj.EngineObj, key string) []sharedMemberValue {
+	found := make(map[string]int)
+	all := make([]sharedMemberValue, 0)
+	for _, m := range o.Members() {
+		val, _ := m.Value(key)
+		seen := make(map[string]bool)
+		for _, v := range valueStrings(val) {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			if i, ok := found[v]; ok {
+				all[i].Members = append(all[i].Members, m)
+				continue
+			}
+			found[v] = len(all)
+			all = append(all, sharedMemberValue{Value: v, Members: []*obj.EngineObj{m}})
+		}
+	}
+	ret := make([]sharedMemberValue, 0)
+	for _, s := range all {
+		if len(s.Members) > 1 {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
 func listMatch[T descriptor.DescriptorValueTypes, C srule.NumericBoundsCheck | srule.StringCheck](
 	operation srule.ContainsOperation,
 	values []T,
@@ -0,0 +1,122 @@
+// Under the Apache-2.0 License
+package matcher
+
+import (
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+// Candidates returns the indexed objects that may match the matchers, in index order.
+//
+// The required contains matchers narrow the candidates: those in the top-level
+// AND, including nested AND collections, that check the object's own values
+// rather than their count.  Each of these only matches an object with a value
+// passing one of its checks, so any other object can't match.  Without a
+// required contains matcher, every object is a candidate.
+//
+// The candidates still need the full IsMatch check.
+func Candidates(x *obj.Index, m *srule.MatchingDescriptorSet) []*obj.EngineObj {
+	if m == nil {
+		return nil
+	}
+	var positions []int
+	narrowed := false
+	for _, c := range requiredContains(m, nil) {
+		found := containsCandidates(x, c)
+		if !narrowed {
+			positions = found
+			narrowed = true
+		} else {
+			positions = intersectSorted(positions, found)
+		}
+		if len(positions) == 0 {
+			return nil
+		}
+	}
+	if !narrowed {
+		return x.Objects()
+	}
+	return x.At(positions)
+}
+
+// requiredContains collects the contains matchers that every matching object must pass.
+func requiredContains(m *srule.MatchingDescriptorSet, ret []*srule.ContainsMatcher) []*srule.ContainsMatcher {
+	for i := range m.Contains {
+		c := &m.Contains[i]
+		if !c.Count && c.Members == srule.ObjectValues && c.Checks.Count() > 0 {
+			ret = append(ret, c)
+		}
+	}
+	for _, c := range m.Collection {
+		if c.Operation == srule.AndCollection && c.Matchers != nil {
+			ret = requiredContains(c.Matchers, ret)
+		}
+	}
+	return ret
+}
+
+// containsCandidates returns the positions of the objects with a key value that passes one of the checks.
+//
+// Every contains operation fails when no value passes a check, so these are the only objects it can match.
+func containsCandidates(x *obj.Index, c *srule.ContainsMatcher) []int {
+	found := make([]int, 0)
+	if len(c.Checks.Text) > 0 {
+		found = x.MatchText(c.Key, func(v string) bool {
+			for _, t := range c.Checks.Text {
+				if t.Matches(v) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if len(c.Checks.Numeric) > 0 {
+		found = unionSorted(found, x.MatchNumber(c.Key, func(v float64) bool {
+			for _, n := range c.Checks.Numeric {
+				if v >= n.Min && v <= n.Max {
+					return true
+				}
+			}
+			return false
+		}))
+	}
+	return found
+}
+
+func intersectSorted(a, b []int) []int {
+	ret := make([]int, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			ret = append(ret, a[i])
+			i++
+			j++
+		}
+	}
+	return ret
+}
+
+func unionSorted(a, b []int) []int {
+	ret := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			ret = append(ret, a[i])
+			i++
+		case a[i] > b[j]:
+			ret = append(ret, b[j])
+			j++
+		default:
+			ret = append(ret, a[i])
+			i++
+			j++
+		}
+	}
+	ret = append(ret, a[i:]...)
+	return append(ret, b[j:]...)
+}
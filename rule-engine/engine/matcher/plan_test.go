@@ -0,0 +1,160 @@
+// Under the Apache-2.0 License
+package matcher_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+var planTypes = []string{"field", "source", "test", "requirement"}
+
+// planObjects creates objects with a data type, a name, and a size, spread over the values.
+func planObjects(t testing.TB, n int) []*obj.EngineObj {
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "enum", "key": "data-type", "enum": ["field", "source", "test", "requirement"], "maximumCount": 1},
+			{"type": "free", "key": "name", "maximumCount": 2},
+			{"type": "number", "key": "size", "minimum": 0, "maximum": 100, "maximumCount": 1}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	factory := obj.NewObjFactory(descriptors)
+	ret := make([]*obj.EngineObj, n)
+	for i := range ret {
+		ob := factory.Empty(obj.ObjSource{})
+		if i%7 != 0 {
+			// Some objects have no data type at all.
+			ob.Add("data-type", obj.DescriptorValues{Text: []string{planTypes[i%len(planTypes)]}})
+		}
+		ob.Add("name", obj.DescriptorValues{Text: []string{fmt.Sprintf("n%d", i%50)}})
+		ob.Add("size", obj.DescriptorValues{Number: []float64{float64(i % 100)}})
+		o := ob.Seal()
+		o.Id = fmt.Sprintf("o%d", i)
+		ret[i] = o
+	}
+	return ret
+}
+
+func planText(op srule.ContainsOperation, key string, patterns ...string) srule.ContainsMatcher {
+	ret := srule.ContainsMatcher{Operation: op, Key: key}
+	for _, p := range patterns {
+		ret.Checks.Text = append(ret.Checks.Text, srule.StringCheck{R: regexp.MustCompile(p)})
+	}
+	return ret
+}
+
+func planSize(op srule.ContainsOperation, count bool, lo, hi float64) srule.ContainsMatcher {
+	return srule.ContainsMatcher{
+		Operation: op,
+		Count:     count,
+		Key:       "size",
+		Checks:    srule.ValueCheckSet{Numeric: []srule.NumericBoundsCheck{{Min: lo, Max: hi}}},
+	}
+}
+
+// planMatchers covers required contains matchers, and the matchers that can't narrow the candidates.
+func planMatchers() map[string]*srule.MatchingDescriptorSet {
+	return map[string]*srule.MatchingDescriptorSet{
+		"exactly":   {Contains: []srule.ContainsMatcher{planText(srule.ContainsExactly, "data-type", "field")}},
+		"substring": {Contains: []srule.ContainsMatcher{planText(srule.ContainsSome, "data-type", "e")}},
+		"only":      {Contains: []srule.ContainsMatcher{planText(srule.ContainsOnly, "data-type", "^test$", "^source$")}},
+		"all":       {Contains: []srule.ContainsMatcher{planText(srule.ContainsAll, "name", "^n1$", "^n2$")}},
+		"and": {Contains: []srule.ContainsMatcher{
+			planText(srule.ContainsExactly, "data-type", "requirement"),
+			planSize(srule.ContainsSome, false, 10, 40),
+		}},
+		"nested-and": {Collection: []srule.CollectionMatcher{{
+			Operation: srule.AndCollection,
+			Matchers:  &srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{planText(srule.ContainsSome, "name", "^n3")}},
+		}}},
+		"or": {Collection: []srule.CollectionMatcher{{
+			Operation: srule.OrCollection,
+			Matchers: &srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+				planText(srule.ContainsSome, "data-type", "field"),
+				planSize(srule.ContainsSome, false, 90, 100),
+			}},
+		}}},
+		"not": {Collection: []srule.CollectionMatcher{{
+			Operation: srule.NotCollection,
+			Matchers:  &srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{planText(srule.ContainsSome, "data-type", "field")}},
+		}}},
+		"count":   {Contains: []srule.ContainsMatcher{planSize(srule.ContainsExactly, true, 0, 0)}},
+		"missing": {Contains: []srule.ContainsMatcher{planText(srule.ContainsSome, "undefined", "x")}},
+		"empty":   {},
+	}
+}
+
+func Test_Candidates(t *testing.T) {
+	all := planObjects(t, 500)
+	index := obj.NewIndex(all)
+	for name, m := range planMatchers() {
+		t.Run(name, func(t *testing.T) {
+			expected := matchedIds(all, m)
+			found := matchedIds(matcher.Candidates(index, m), m)
+			if diff := cmp.Diff(expected, found); diff != "" {
+				t.Errorf("indexed matches differ from the full scan (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if c := matcher.Candidates(index, planMatchers()["exactly"]); len(c) >= len(all)/2 {
+		t.Errorf("expected the index to narrow the candidates, found %d of %d", len(c), len(all))
+	}
+	if c := matcher.Candidates(index, planMatchers()["not"]); len(c) != len(all) {
+		t.Errorf("expected a negated matcher to keep every candidate, found %d of %d", len(c), len(all))
+	}
+}
+
+func matchedIds(objs []*obj.EngineObj, m *srule.MatchingDescriptorSet) []string {
+	ret := make([]string, 0)
+	for _, o := range objs {
+		if ok, _ := matcher.IsMatch(o, m); ok {
+			ret = append(ret, o.Id)
+		}
+	}
+	return ret
+}
+
+// Benchmark_Rules checks typical rules, which start with a data type matcher, as the engine does.
+func Benchmark_Rules(b *testing.B) {
+	all := planObjects(b, 20000)
+	matchers := make([]*srule.MatchingDescriptorSet, 0)
+	for _, dt := range planTypes {
+		for n := 0; n < 5; n++ {
+			matchers = append(matchers, &srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{
+				planText(srule.ContainsExactly, "data-type", "^"+dt+"$"),
+				planText(srule.ContainsSome, "name", fmt.Sprintf("^n%d$", n)),
+			}})
+		}
+	}
+	b.Run("full-scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, m := range matchers {
+				matchedIds(all, m)
+			}
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			// The engine builds the index once for each batch of objects.
+			index := obj.NewIndex(all)
+			for _, m := range matchers {
+				matchedIds(matcher.Candidates(index, m), m)
+			}
+		}
+	})
+}
@@ -19,6 +19,7 @@ type MatcherMismatch struct {
 	Obj        *obj.EngineObj
 	Collection *MismatchCollection
 	Contains   *MismatchContains
+	Unique     *MismatchUnique
 }
 
 func (m MatcherMismatch) String() string {
@@ -29,6 +30,9 @@ func (m MatcherMismatch) String() string {
 	if m.Contains != nil {
 		ret += m.Contains.String(m.Obj)
 	}
+	if m.Unique != nil {
+		ret += m.Unique.String(m.Obj)
+	}
 	return ret
 }
 
@@ -62,6 +66,9 @@ func (m MismatchCollection) String(parent *obj.EngineObj) string {
 	for _, p := range m.Matcher.Contains {
 		parts = append(parts, (MismatchContains{Contains: &p}).String(parent))
 	}
+	for _, p := range m.Matcher.Unique {
+		parts = append(parts, (MismatchUnique{Unique: &p}).String(parent))
+	}
 	return "(" + strings.Join(parts, join) + ")"
 }
 
@@ -70,15 +77,35 @@ type MismatchContains struct {
 }
 
 func (m MismatchContains) String(parent *obj.EngineObj) string {
-	ret := m.Contains.Key
-	if m.Contains.Distinct {
+	val := containsValue(parent, m.Contains)
+	return describeContains(m.Contains) + " but has (" + describeValues(val) + ")"
+}
+
+type MismatchUnique struct {
+	Unique *srule.UniqueMatcher
+}
+
+func (m MismatchUnique) String(parent *obj.EngineObj) string {
+	return describeUnique(m.Unique) + " but " + describeShared(sharedMemberValues(parent, m.Unique.Key))
+}
+
+// describeContains returns the English description of the contains condition.
+func describeContains(c *srule.ContainsMatcher) string {
+	ret := c.Key
+	if c.Members == srule.UnionMembers {
+		ret = "union of members' " + ret
+	}
+	if c.Distinct {
 		ret = "distinct " + ret
 	}
-	if m.Contains.Count {
+	if c.Count {
 		ret = "count of " + ret
 	}
+	if c.Members == srule.EachMember {
+		ret = "each member's " + ret
+	}
 
-	switch m.Contains.Operation {
+	switch c.Operation {
 	case srule.ContainsAll:
 		ret += " contains all "
 	case srule.ContainsExactly:
@@ -92,40 +119,59 @@ func (m MismatchContains) String(parent *obj.EngineObj) string {
 	}
 
 	first := true
-	for _, c := range m.Contains.Checks.Numeric {
+	for _, n := range c.Checks.Numeric {
 		if first {
 			first = false
 		} else {
 			ret += ", "
 		}
-		ret += fmt.Sprintf("range [%f, %f]", c.Min, c.Max)
+		ret += fmt.Sprintf("range [%f, %f]", n.Min, n.Max)
 	}
-	for _, c := range m.Contains.Checks.Text {
+	for _, t := range c.Checks.Text {
 		if first {
 			first = false
 		} else {
 			ret += ", "
 		}
-		ret += "'" + c.R.String() + "'"
+		ret += "'" + t.R.String() + "'"
 	}
-	ret += " but has ("
-	val, _ := parent.Value(m.Contains.Key)
-	first = true
-	for _, v := range val.Number {
-		if first {
-			first = false
-		} else {
-			ret += ", "
+	return ret
+}
+
+// describeValues returns the comma separated list of values.
+func describeValues(val obj.DescriptorValues) string {
+	return strings.Join(valueStrings(val), ", ")
+}
+
+// describeUnique returns the English description of the unique condition.
+func describeUnique(u *srule.UniqueMatcher) string {
+	return "members have unique " + u.Key
+}
+
+// describeShared returns the English description of the values shared between members.
+func describeShared(shared []sharedMemberValue) string {
+	if len(shared) <= 0 {
+		return "no members share a value"
+	}
+	parts := make([]string, len(shared))
+	for i, s := range shared {
+		ids := make([]string, len(s.Members))
+		for j, m := range s.Members {
+			ids[j] = m.Id
 		}
-		ret += strconv.FormatFloat(v, 'f', 4, 64)
+		parts[i] = s.Value + " is shared by " + strings.Join(ids, ", ")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// valueStrings returns each value in the same form as describeValues.
+func valueStrings(val obj.DescriptorValues) []string {
+	ret := make([]string, 0, val.Count())
+	for _, v := range val.Number {
+		ret = append(ret, strconv.FormatFloat(v, 'f', 4, 64))
 	}
 	for _, v := range val.Text {
-		if first {
-			first = false
-		} else {
-			ret += ", "
-		}
-		ret += "'" + v + "'"
+		ret = append(ret, "'"+v+"'")
 	}
-	return ret + ")"
+	return ret
 }
@@ -8,9 +8,12 @@ import (
 )
 
 type engineObjBuilder struct {
-	source ObjSource
-	id     string
-	ont    *sont.AllowedDescriptors
+	source    ObjSource
+	id        string
+	ont       *sont.AllowedDescriptors
+	members   []*EngineObj
+	identity  *objIdentity
+	ancestors AncestorSet
 
 	numeric map[string]descriptor.DescriptorValueBuilder[float64]
 	enum    map[string]descriptor.DescriptorValueBuilder[string]
@@ -31,10 +34,11 @@ func newObjBuilder(
 			Construct: construct,
 			Source:    source,
 		},
-		ont:     ont,
-		numeric: make(map[string]descriptor.DescriptorValueBuilder[float64]),
-		enum:    make(map[string]descriptor.DescriptorValueBuilder[string]),
-		free:    make(map[string]descriptor.DescriptorValueBuilder[string]),
+		ont:      ont,
+		identity: &objIdentity{},
+		numeric:  make(map[string]descriptor.DescriptorValueBuilder[float64]),
+		enum:     make(map[string]descriptor.DescriptorValueBuilder[string]),
+		free:     make(map[string]descriptor.DescriptorValueBuilder[string]),
 	}
 }
 
@@ -44,12 +48,15 @@ func (o *EngineObj) Alter() EngineObjBuilder {
 	}
 
 	return &engineObjBuilder{
-		source:  o.Source,
-		id:      o.Id, // Note: should probably make an indicator this was a modification
-		ont:     o.ont,
-		numeric: mutateDescriptorMap(o.Numeric),
-		enum:    mutateDescriptorMap(o.Enum),
-		free:    mutateDescriptorMap(o.Free),
+		source:    o.Source,
+		id:        o.Id, // Note: should probably make an indicator this was a modification
+		ont:       o.ont,
+		members:   o.members,
+		identity:  o.identity,
+		ancestors: o.ancestors,
+		numeric:   mutateDescriptorMap(o.Numeric),
+		enum:      mutateDescriptorMap(o.Enum),
+		free:      mutateDescriptorMap(o.Free),
 	}
 }
 
@@ -145,11 +152,15 @@ func (o *engineObjBuilder) Seal() *EngineObj {
 		return nil
 	}
 	return &EngineObj{
-		Id:      o.id,
-		Source:  o.source,
-		Numeric: sealDescriptorMap(o.numeric),
-		Enum:    sealDescriptorMap(o.enum),
-		Free:    sealDescriptorMap(o.free),
+		Id:        o.id,
+		Source:    o.source,
+		Numeric:   sealDescriptorMap(o.numeric),
+		Enum:      sealDescriptorMap(o.enum),
+		Free:      sealDescriptorMap(o.free),
+		members:   o.members,
+		ont:       o.ont,
+		identity:  o.identity,
+		ancestors: o.ancestors,
 	}
 }
 
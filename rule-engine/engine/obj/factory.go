@@ -64,14 +64,23 @@ func (f *objFactory) FromDocument(doc *sdoc.DocumentObject) *EngineObj {
 
 // FromGroup creates a new engine object for a self-organizing group.
 //
-// `groupSrc` refers to the identifier for the SOG rule that defines the group.
-func (f *objFactory) FromGroup(members []*EngineObj, groupSrc string) *EngineObj {
+// `groupSrc` refers to the identifier for the SOG rule that defines the group,
+// and `id` to the group instance.
+// The object's values join together all the members' values, and it keeps the
+// members so matchers can inspect each member's values.  It also has the
+// member meta-descriptors.
+func (f *objFactory) FromGroup(members []*EngineObj, groupSrc string, id string) *EngineObj {
 	if f == nil || members == nil || len(members) <= 0 {
 		return nil
 	}
 
-	// TODO change id argument to instead be from the shared key value(s).
-	ret := newObjBuilder(copySrc(members), &groupSrc, groupSrc, nil, f.ont)
+	ret := newObjBuilder(copySrc(members), &groupSrc, id, nil, f.ont)
+	ret.members = make([]*EngineObj, len(members))
+	copy(ret.members, members)
+	ret.ancestors = make(AncestorSet)
+	for _, m := range members {
+		ret.ancestors.Add(m)
+	}
 	for _, m := range members {
 		for k, vs := range m.Enum {
 			appendBuilder(k, ret.enum, vs)
@@ -83,16 +92,27 @@ func (f *objFactory) FromGroup(members []*EngineObj, groupSrc string) *EngineObj
 			appendBuilder(k, ret.numeric, vs)
 		}
 	}
+
+	// The meta-descriptors are always present, even when the ontology doesn't include them.
+	count := descriptor.NewNumericBuilder(false)
+	count.AddList([]float64{float64(len(members))})
+	ret.numeric[sont.MemberCountKey] = count
+	ids := descriptor.NewTextBuilder(false, true)
+	for _, m := range members {
+		ids.AddList([]string{m.Id})
+	}
+	ret.free[sont.MemberIdsKey] = ids
 	return ret.Seal()
 }
 
 func (f *objFactory) Empty(source ObjSource) EngineObjBuilder {
 	return &engineObjBuilder{
-		source:  source,
-		ont:     f.ont,
-		numeric: make(map[string]descriptor.DescriptorValueBuilder[float64]),
-		enum:    make(map[string]descriptor.DescriptorValueBuilder[string]),
-		free:    make(map[string]descriptor.DescriptorValueBuilder[string]),
+		source:   source,
+		ont:      f.ont,
+		identity: &objIdentity{},
+		numeric:  make(map[string]descriptor.DescriptorValueBuilder[float64]),
+		enum:     make(map[string]descriptor.DescriptorValueBuilder[string]),
+		free:     make(map[string]descriptor.DescriptorValueBuilder[string]),
 	}
 }
 
@@ -111,8 +131,8 @@ func appendBuilder[T descriptor.DescriptorValueTypes](
 ) {
 	v, ok := m[key]
 	if !ok {
-		v = add.Copy()
-		m[key] = v
+		m[key] = add.Copy()
+		return
 	}
-	v.Add(v)
+	v.Add(add.Copy())
 }
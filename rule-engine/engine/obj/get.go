@@ -3,10 +3,12 @@ package obj
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
 )
 
 // Value returns a descriptor value list for the given key.
@@ -37,6 +39,36 @@ func (o *EngineObj) Value(key string) (DescriptorValues, bool) {
 	return DescriptorValues{}, false
 }
 
+// SharedValue returns the key's values in a normal form for comparing objects.
+//
+// Following the ontology, text values of a case-insensitive key are lower case,
+// and a distinct key has no repeated values.  The values are sorted.
+func (o *EngineObj) SharedValue(key string) DescriptorValues {
+	val, distinct := o.Value(key)
+	if f, ok := o.Free[key]; ok && !f.IsCaseSensitive() {
+		lower := make([]string, len(val.Text))
+		for i, t := range val.Text {
+			lower[i] = strings.ToLower(t)
+		}
+		val.Text = lower
+	}
+	if distinct {
+		val = val.Distinct()
+	}
+	ret := DescriptorValues{}
+	if val.Number != nil {
+		ret.Number = make([]float64, len(val.Number))
+		copy(ret.Number, val.Number)
+		sort.Float64s(ret.Number)
+	}
+	if val.Text != nil {
+		ret.Text = make([]string, len(val.Text))
+		copy(ret.Text, val.Text)
+		sort.Strings(ret.Text)
+	}
+	return ret
+}
+
 // Count returns the number of values for the key in this object.
 //
 // All objects contain every key, though the default value is an empty list.
@@ -99,6 +131,29 @@ func (d DescriptorValues) Count() int {
 	return len(d.Number) + len(d.Text)
 }
 
+// AllSources returns the sources for the object along with the sources of every parent.
+//
+// Synthetic objects do not have their own sources, so this is how they link
+// back to the member documents.  Each parent is visited at most once.
+func (s ObjSource) AllSources() []sources.Source {
+	ret := make([]sources.Source, 0, len(s.Source))
+	ret = append(ret, s.Source...)
+	visited := make(map[*ObjSource]bool)
+	stack := make([]*ObjSource, len(s.Parents))
+	copy(stack, s.Parents)
+	for len(stack) > 0 {
+		curr := stack[0]
+		stack = stack[1:]
+		if curr == nil || visited[curr] {
+			continue
+		}
+		visited[curr] = true
+		ret = append(ret, curr.Source...)
+		stack = append(stack, curr.Parents...)
+	}
+	return ret
+}
+
 func (s ObjSource) String() string {
 	ret := ""
 	if s.Construct != nil {
@@ -137,3 +192,67 @@ func (s ObjSource) String() string {
 	}
 	return ret
 }
+
+// Members returns the objects joined into this SOG object.
+//
+// An object that is not a SOG is its own single member.
+func (o *EngineObj) Members() []*EngineObj {
+	if o.members == nil {
+		return []*EngineObj{o}
+	}
+	return o.members
+}
+
+// MemberValues returns the values for the key of each member, in member order.
+func (o *EngineObj) MemberValues(key string) []DescriptorValues {
+	members := o.Members()
+	ret := make([]DescriptorValues, len(members))
+	for i, m := range members {
+		ret[i], _ = m.Value(key)
+	}
+	return ret
+}
+
+// UnionValue returns the distinct values for the key across all the members.
+func (o *EngineObj) UnionValue(key string) DescriptorValues {
+	var ret DescriptorValues
+	for _, v := range o.MemberValues(key) {
+		if v.Number != nil {
+			ret.Number = append(ret.Number, v.Number...)
+		}
+		if v.Text != nil {
+			ret.Text = append(ret.Text, v.Text...)
+		}
+	}
+	return ret.Distinct()
+}
+
+// Ancestors returns the identities of the objects joined into this SOG object, and of their own ancestors.
+//
+// This is empty for objects that aren't a SOG.
+func (o *EngineObj) Ancestors() AncestorSet {
+	return o.ancestors
+}
+
+// Has checks whether the object, or any copy of it made by Alter or Seal, is in the set.
+func (s AncestorSet) Has(o *EngineObj) bool {
+	return o != nil && o.identity != nil && s[o.identity]
+}
+
+// Add adds the object and all of its ancestors into the set.
+func (s AncestorSet) Add(o *EngineObj) {
+	if o == nil {
+		return
+	}
+	if o.identity != nil {
+		s[o.identity] = true
+	}
+	s.AddAll(o.ancestors)
+}
+
+// AddAll adds every identity in the other set.
+func (s AncestorSet) AddAll(other AncestorSet) {
+	for a := range other {
+		s[a] = true
+	}
+}
@@ -0,0 +1,96 @@
+// Under the Apache-2.0 License
+package obj
+
+import (
+	"sort"
+)
+
+// Index maps each descriptor key and value to the objects that have it.
+//
+// Objects are referenced by their position in the indexed list, so lookups
+// return them in the original order.
+type Index struct {
+	objs   []*EngineObj
+	text   map[string]map[string][]int
+	number map[string]map[float64][]int
+}
+
+// NewIndex indexes the values of every key in the objects.
+func NewIndex(objs []*EngineObj) *Index {
+	ret := &Index{
+		objs:   objs,
+		text:   make(map[string]map[string][]int),
+		number: make(map[string]map[float64][]int),
+	}
+	for i, o := range objs {
+		for k, v := range o.Enum {
+			indexValues(ret.text, k, v.List(), i)
+		}
+		for k, v := range o.Free {
+			indexValues(ret.text, k, v.List(), i)
+		}
+		for k, v := range o.Numeric {
+			indexValues(ret.number, k, v.List(), i)
+		}
+	}
+	return ret
+}
+
+func indexValues[T string | float64](index map[string]map[T][]int, key string, values []T, pos int) {
+	byValue, ok := index[key]
+	if !ok {
+		byValue = make(map[T][]int)
+		index[key] = byValue
+	}
+	for _, v := range values {
+		list := byValue[v]
+		if len(list) > 0 && list[len(list)-1] == pos {
+			// A value repeated in the same object.
+			continue
+		}
+		byValue[v] = append(list, pos)
+	}
+}
+
+// Objects returns the indexed objects.
+func (x *Index) Objects() []*EngineObj {
+	return x.objs
+}
+
+// At returns the objects at the positions.
+func (x *Index) At(positions []int) []*EngineObj {
+	ret := make([]*EngineObj, len(positions))
+	for i, p := range positions {
+		ret[i] = x.objs[p]
+	}
+	return ret
+}
+
+// MatchText returns the sorted positions of the objects with a text value for the key that passes the check.
+//
+// The check runs once per distinct value, rather than once per object.
+func (x *Index) MatchText(key string, check func(string) bool) []int {
+	return matchValues(x.text[key], check)
+}
+
+// MatchNumber returns the sorted positions of the objects with a numeric value for the key that passes the check.
+func (x *Index) MatchNumber(key string, check func(float64) bool) []int {
+	return matchValues(x.number[key], check)
+}
+
+func matchValues[T string | float64](byValue map[T][]int, check func(T) bool) []int {
+	found := make(map[int]bool)
+	for v, positions := range byValue {
+		if check(v) {
+			for _, p := range positions {
+				found[p] = true
+			}
+		}
+	}
+	ret := make([]int, 0, len(found))
+	for p := range found {
+		ret = append(ret, p)
+	}
+	sort.Ints(ret)
+	return ret
+}
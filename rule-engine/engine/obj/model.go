@@ -44,9 +44,25 @@ type EngineObj struct {
 	Enum    map[string]descriptor.ImmutableDescriptorValue[string]
 	Free    map[string]descriptor.ImmutableDescriptorValue[string]
 
-	ont *sont.AllowedDescriptors
+	// members are the objects joined into a SOG object; nil for other objects.
+	members []*EngineObj
+	ont     *sont.AllowedDescriptors
+
+	// identity and ancestors survive the copies made by Alter and Seal.
+	identity  *objIdentity
+	ancestors AncestorSet
+}
+
+// objIdentity distinguishes an object from every other object, through its pointer.
+type objIdentity struct {
+	_ byte // A zero size struct may share its address with other values.
 }
 
+// AncestorSet contains the identities of objects joined, directly or through other SOGs, into a SOG object.
+//
+// Treat it as read-only once returned from an object.
+type AncestorSet map[*objIdentity]bool
+
 type EngineObjBuilder interface {
 	Add(key string, val DescriptorValues)
 	AddDistinct(key string, val DescriptorValues)
@@ -63,8 +79,9 @@ type ObjFactory interface {
 
 	// FromGroup creates a synthetic object based on a group rule.
 	//
-	// The group rule may need to perform alterations upon the generated object.
-	FromGroup(members []*EngineObj, groupSrc string) *EngineObj
+	// The `id` identifies the group instance.  The group rule may need to perform
+	// alterations upon the generated object.
+	FromGroup(members []*EngineObj, groupSrc string, id string) *EngineObj
 
 	// Empty creates an object builder.
 	Empty(source ObjSource) EngineObjBuilder
@@ -4,14 +4,24 @@
 package runner
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
 )
 
+// MatchConvergence checks the members of one SOG instance against the convergence.
+//
+// Returns nil if the members converge.  Otherwise, the problem names the
+// dominant values, if the requirement has any, and only the deviating members.
 func MatchConvergence(
 	groupId string,
+	sogId string,
 	objs []*obj.EngineObj,
 	c *srule.Convergence,
 	ont *sont.AllowedDescriptors,
@@ -23,31 +33,258 @@ func MatchConvergence(
 	}
 	groups := findGroup(objs, d, c.Distinct)
 
+	var res *convResult
 	switch c.Requires {
 	case srule.AllMatch:
-		return convMatchRes(groupId, c, matchAll(groups))
+		res = matchAll(groups)
 	case srule.Disjoint:
-		return convMatchRes(groupId, c, matchDisjoint(objs, groups))
+		res = matchDisjoint(objs, d, c.Key)
+	case srule.SubsetOf:
+		res = matchReference(objs, c, d, true)
+	case srule.SupersetOf:
+		res = matchReference(objs, c, d, false)
+	case srule.AtLeastNDistinct:
+		res = matchAtLeast(groups, c.Count)
+	case srule.WithinTolerance:
+		res = matchTolerance(groups, c.Tolerance)
+	case srule.Majority:
+		res = matchMajority(groups)
 	default:
 		// FIXME Should be a Problem
 		return nil
 	}
+	if res == nil {
+		return nil
+	}
+	return &ConvProblem{
+		GroupId:    groupId,
+		SogId:      sogId,
+		Members:    objs,
+		Dominant:   res.dominant,
+		Mismatched: res.mismatched,
+		Conv:       c,
+		Reason:     res.reason,
+	}
+}
+
+// convResult is a failed convergence requirement.
+type convResult struct {
+	dominant   *MemberValues
+	mismatched []MemberValues
+	reason     string
 }
 
-func matchAll(ml []MemberValues) []MemberValues {
-	if len(ml) == 1 {
+func matchAll(ml []MemberValues) *convResult {
+	if len(ml) <= 1 {
 		// 1 value, which means that they all share the same value.
 		return nil
 	}
-	return ml
+	return outliers(ml, "")
+}
+
+// matchDisjoint finds the members that share a value with another member.
+//
+// Each deviating member lists just the values it shares.
+func matchDisjoint(objs []*obj.EngineObj, d *sont.TypedDescriptor, key string) *convResult {
+	tf := textTransform(d)
+	tc := make(map[string]int)
+	nc := make(map[float64]int)
+	for _, o := range objs {
+		v, _ := o.Value(key)
+		tm := make(map[string]int)
+		nm := make(map[float64]int)
+		loadMaps(tm, nm, v, tf)
+		for t := range tm {
+			tc[t]++
+		}
+		for n := range nm {
+			nc[n]++
+		}
+	}
+
+	ret := make([]MemberValues, 0)
+	for _, o := range objs {
+		v, _ := o.Value(key)
+		shared := MemberValues{Members: []*obj.EngineObj{o}}
+		seen := make(map[string]bool)
+		for _, t := range v.Text {
+			k := tf.Transform(t)
+			if tc[k] > 1 && !seen[k] {
+				seen[k] = true
+				shared.Text = append(shared.Text, t)
+			}
+		}
+		seenN := make(map[float64]bool)
+		for _, n := range v.Number {
+			if nc[n] > 1 && !seenN[n] {
+				seenN[n] = true
+				shared.Number = append(shared.Number, n)
+			}
+		}
+		if len(shared.Text)+len(shared.Number) > 0 {
+			ret = append(ret, shared)
+		}
+	}
+	if len(ret) <= 0 {
+		return nil
+	}
+	return &convResult{mismatched: ret, reason: "values are also in other members"}
+}
+
+// matchReference checks each member against the union of the reference members' values.
+//
+// For a subset, the member's values must all be in the reference values.  For a
+// superset, the member's values must include every reference value.  The
+// reference members are not checked against themselves.
+func matchReference(
+	objs []*obj.EngineObj,
+	c *srule.Convergence,
+	d *sont.TypedDescriptor,
+	subset bool,
+) *convResult {
+	tf := textTransform(d)
+	refTm := make(map[string]int)
+	refNm := make(map[float64]int)
+	ref := &MemberValues{Members: make([]*obj.EngineObj, 0)}
+	others := make([]*obj.EngineObj, 0, len(objs))
+	for _, o := range objs {
+		if ok, _ := matcher.IsMatch(o, c.Reference); ok {
+			v, _ := o.Value(c.Key)
+			loadMaps(refTm, refNm, v, tf)
+			ref.Members = append(ref.Members, o)
+			ref.Text = append(ref.Text, v.Text...)
+			ref.Number = append(ref.Number, v.Number...)
+		} else {
+			others = append(others, o)
+		}
+	}
+	if len(ref.Members) <= 0 {
+		return &convResult{reason: "no member matches the reference matchers"}
+	}
+	union := (obj.DescriptorValues{Text: ref.Text, Number: ref.Number}).Distinct()
+	ref.Text = union.Text
+	ref.Number = union.Number
+
+	ret := make([]MemberValues, 0)
+	for _, o := range others {
+		v, _ := o.Value(c.Key)
+		tm := make(map[string]int)
+		nm := make(map[float64]int)
+		loadMaps(tm, nm, v, tf)
+		ok := false
+		if subset {
+			ok = containsKeys(refTm, tm) && containsKeys(refNm, nm)
+		} else {
+			ok = containsKeys(tm, refTm) && containsKeys(nm, refNm)
+		}
+		if !ok {
+			ret = append(ret, *newMemberValues(o, v))
+		}
+	}
+	if len(ret) <= 0 {
+		return nil
+	}
+	if subset {
+		return &convResult{dominant: ref, mismatched: ret, reason: "values are not a subset of the reference values"}
+	}
+	return &convResult{dominant: ref, mismatched: ret, reason: "values are not a superset of the reference values"}
+}
+
+// containsKeys returns true if every key in the sub map is also in the super map.
+func containsKeys[T string | float64](super map[T]int, sub map[T]int) bool {
+	for k := range sub {
+		if super[k] <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAtLeast checks the number of different member values.
+//
+// No single member deviates, so the problem is about the whole SOG.
+func matchAtLeast(ml []MemberValues, count int) *convResult {
+	if len(ml) >= count {
+		return nil
+	}
+	return &convResult{reason: fmt.Sprintf("%d distinct values, requires at least %d", len(ml), count)}
 }
 
-func matchDisjoint(objs []*obj.EngineObj, ml []MemberValues) []MemberValues {
-	if len(objs) == len(ml) {
-		// Each object is its own group, so they are all distinct from each other.
+// matchTolerance checks the spread of the numeric values across all the members.
+//
+// When the spread is too large, this returns the members with the smallest and
+// largest values.
+func matchTolerance(ml []MemberValues, tolerance float64) *convResult {
+	low := math.Inf(1)
+	high := math.Inf(-1)
+	for _, m := range ml {
+		for _, n := range m.Number {
+			low = math.Min(low, n)
+			high = math.Max(high, n)
+		}
+	}
+	if high-low <= tolerance {
+		// Also covers the no-values case, where high < low.
 		return nil
 	}
-	return ml
+	ret := make([]MemberValues, 0)
+	for _, m := range ml {
+		for _, n := range m.Number {
+			if n == low || n == high {
+				ret = append(ret, m)
+				break
+			}
+		}
+	}
+	return &convResult{mismatched: ret, reason: fmt.Sprintf(
+		"values differ by %s, more than the tolerance %s",
+		strconv.FormatFloat(high-low, 'f', -1, 64),
+		strconv.FormatFloat(tolerance, 'f', -1, 64),
+	)}
+}
+
+// matchMajority returns the members whose values differ from the values of more than half the members.
+//
+// Without such a majority, every member deviates.
+func matchMajority(ml []MemberValues) *convResult {
+	if len(ml) <= 1 {
+		return nil
+	}
+	ret := outliers(ml, "")
+	total := 0
+	for _, m := range ml {
+		total += len(m.Members)
+	}
+	if len(ret.dominant.Members)*2 <= total {
+		return &convResult{mismatched: ml, reason: "no values are shared by a majority of the members"}
+	}
+	return ret
+}
+
+// outliers splits the value groups into the dominant group, with the most members, and the rest.
+//
+// On a tie, the values found first are dominant.
+func outliers(ml []MemberValues, reason string) *convResult {
+	most := 0
+	for i, m := range ml {
+		if len(m.Members) > len(ml[most].Members) {
+			most = i
+		}
+	}
+	ret := make([]MemberValues, 0, len(ml)-1)
+	for i, m := range ml {
+		if i != most {
+			ret = append(ret, m)
+		}
+	}
+	return &convResult{dominant: &ml[most], mismatched: ret, reason: reason}
+}
+
+func textTransform(d *sont.TypedDescriptor) descriptor.DescriptorValueTypeTransform[string] {
+	if d.IsCaseSensitive() {
+		return descriptor.StringTransform
+	}
+	return descriptor.StringLowerTransform
 }
 
 func newMemberValues(o *obj.EngineObj, v obj.DescriptorValues) *MemberValues {
@@ -78,10 +315,7 @@ func newDMemberValues(
 		tm:        make(map[string]int),
 		nm:        make(map[float64]int),
 		distinct:  forceDistinct || d.IsDistinct(),
-		textTform: descriptor.StringLowerTransform,
-	}
-	if d.IsCaseSensitive() {
-		ret.textTform = descriptor.StringTransform
+		textTform: textTransform(d),
 	}
 	loadMaps(ret.tm, ret.nm, v, ret.textTform)
 	return ret
@@ -148,7 +382,6 @@ func (d *dMemberValues) match(v obj.DescriptorValues) bool {
 func findGroup(objs []*obj.EngineObj, d *sont.TypedDescriptor, distinct bool) []MemberValues {
 	key := d.KeyName()
 	dGroups := make([]*dMemberValues, 0)
-	groups := make([]MemberValues, 0)
 	for _, o := range objs {
 		v, _ := o.Value(key)
 		missing := true
@@ -159,28 +392,14 @@ func findGroup(objs []*obj.EngineObj, d *sont.TypedDescriptor, distinct bool) []
 			}
 		}
 		if missing {
-			dmv := newDMemberValues(o, v, d, distinct)
-			dGroups = append(dGroups, dmv)
-			// While this makes a copy of the object, it should be
-			// a shallow copy, meaning the list of members is shared,
-			// and that's the important part of this logic.
-			groups = append(groups, *dmv.m)
+			dGroups = append(dGroups, newDMemberValues(o, v, d, distinct))
 		}
 	}
-	return groups
-}
-
-func convMatchRes(
-	groupId string,
-	conv *srule.Convergence,
-	res []MemberValues,
-) *ConvProblem {
-	if res == nil {
-		return nil
-	}
-	return &ConvProblem{
-		GroupId:    groupId,
-		Mismatched: res,
-		Conv:       conv,
+	// Copy the groups only after all the members are added; appending to the
+	// member list may replace it, so an earlier copy could miss members.
+	groups := make([]MemberValues, len(dGroups))
+	for i, g := range dGroups {
+		groups[i] = *g.m
 	}
+	return groups
 }
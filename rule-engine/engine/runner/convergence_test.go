@@ -4,11 +4,18 @@
 package runner
 
 import (
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
 func Test_loadMaps(t *testing.T) {
@@ -74,3 +81,150 @@ func Test_loadMaps(t *testing.T) {
 		}
 	})
 }
+
+func Test_MatchConvergence(t *testing.T) {
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "free", "key": "role", "maximumCount": 1},
+			{"type": "free", "key": "tag", "maximumCount": 10},
+			{"type": "number", "key": "size", "minimum": 0, "maximum": 1000, "maximumCount": 1}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	factory := obj.NewObjFactory(descriptors)
+	mk := func(id string, role string, size float64, tags ...string) *obj.EngineObj {
+		ob := factory.Empty(obj.ObjSource{})
+		if role != "" {
+			ob.Add("role", obj.DescriptorValues{Text: []string{role}})
+		}
+		ob.Add("size", obj.DescriptorValues{Number: []float64{size}})
+		ob.Add("tag", obj.DescriptorValues{Text: tags})
+		o := ob.Seal()
+		o.Id = id
+		return o
+	}
+	canonical := &srule.MatchingDescriptorSet{Contains: []srule.ContainsMatcher{{
+		Operation: srule.ContainsSome,
+		Key:       "role",
+		Checks:    srule.ValueCheckSet{Text: []srule.StringCheck{{R: regexp.MustCompile("^canonical$")}}},
+	}}}
+	objs := []*obj.EngineObj{
+		mk("ref", "canonical", 10, "a", "b"),
+		mk("sub", "", 10, "a"),
+		mk("same", "", 12, "a", "b"),
+		mk("extra", "", 10, "a", "c"),
+	}
+
+	tests := []struct {
+		name     string
+		conv     srule.Convergence
+		expected []string
+		reason   string
+	}{
+		{"all-match", srule.Convergence{Key: "size", Requires: srule.AllMatch},
+			[]string{"same"}, ""},
+		{"disjoint", srule.Convergence{Key: "tag", Requires: srule.Disjoint},
+			[]string{"ref", "sub", "same", "extra"}, "values are also in other members"},
+		{"subset", srule.Convergence{Key: "tag", Requires: srule.SubsetOf, Reference: canonical},
+			[]string{"extra"}, "values are not a subset of the reference values"},
+		{"superset", srule.Convergence{Key: "tag", Requires: srule.SupersetOf, Reference: canonical},
+			[]string{"sub", "extra"}, "values are not a superset of the reference values"},
+		{"no-reference", srule.Convergence{Key: "tag", Requires: srule.SubsetOf, Reference: &srule.MatchingDescriptorSet{
+			Contains: []srule.ContainsMatcher{{Operation: srule.ContainsSome, Key: "role", Checks: srule.ValueCheckSet{
+				Text: []srule.StringCheck{{R: regexp.MustCompile("^other$")}},
+			}}},
+		}}, []string{}, "no member matches the reference matchers"},
+		{"at-least-pass", srule.Convergence{Key: "tag", Requires: srule.AtLeastNDistinct, Count: 3}, nil, ""},
+		{"at-least-fail", srule.Convergence{Key: "size", Requires: srule.AtLeastNDistinct, Count: 3},
+			[]string{}, "2 distinct values, requires at least 3"},
+		{"tolerance-pass", srule.Convergence{Key: "size", Requires: srule.WithinTolerance, Tolerance: 2}, nil, ""},
+		{"tolerance-fail", srule.Convergence{Key: "size", Requires: srule.WithinTolerance, Tolerance: 1},
+			[]string{"ref", "sub", "extra", "same"}, "values differ by 2, more than the tolerance 1"},
+		{"majority", srule.Convergence{Key: "size", Requires: srule.Majority},
+			[]string{"same"}, ""},
+		{"no-majority", srule.Convergence{Key: "tag", Requires: srule.Majority},
+			[]string{"ref", "same", "sub", "extra"}, "no values are shared by a majority of the members"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := MatchConvergence("g", "g(x)", objs, &test.conv, descriptors)
+			if test.expected == nil {
+				if res != nil {
+					t.Fatalf("expected no problem, found %v", res)
+				}
+				return
+			}
+			if res == nil {
+				t.Fatal("expected a problem")
+			}
+			ids := make([]string, 0)
+			for _, m := range res.Mismatched {
+				for _, o := range m.Members {
+					ids = append(ids, o.Id)
+				}
+			}
+			if diff := cmp.Diff(test.expected, ids); diff != "" {
+				t.Errorf("mismatched members (-want +got):\n%s", diff)
+			}
+			if res.Reason != test.reason {
+				t.Errorf("expected reason %q, found %q", test.reason, res.Reason)
+			}
+		})
+	}
+}
+
+func Test_convAsProblems(t *testing.T) {
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "number", "key": "size", "minimum": 0, "maximum": 1000, "maximumCount": 1}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	factory := obj.NewObjFactory(descriptors)
+	objs := make([]*obj.EngineObj, 0)
+	for i, size := range []float64{10, 10, 12, 10, 14} {
+		ob := factory.Empty(obj.ObjSource{})
+		ob.Add("size", obj.DescriptorValues{Number: []float64{size}})
+		o := ob.Seal()
+		o.Id = "m" + strconv.Itoa(i)
+		objs = append(objs, o)
+	}
+
+	conv := &srule.Convergence{Key: "size", Level: "error", Requires: srule.AllMatch}
+	res := MatchConvergence("g", "g(x)", objs, conv, descriptors)
+	probs := convAsProblems(map[string]problem.ProblemLevel{"error": problem.Err}, res)
+	messages := make([]string, len(probs))
+	for i, p := range probs {
+		messages[i] = p.Message
+		if s := p.Subject(); s.SogId != "g(x)" || len(s.ObjectIds) != 1 {
+			t.Errorf("expected a single member of the SOG, found %v", s)
+		}
+	}
+	expected := []string{
+		"Group g: Convergence size violation (error) in g(x): m2 has (12.0000) instead of (10.0000) like 3 members",
+		"Group g: Convergence size violation (error) in g(x): m4 has (14.0000) instead of (10.0000) like 3 members",
+	}
+	if diff := cmp.Diff(expected, messages); diff != "" {
+		t.Errorf("messages (-want +got):\n%s", diff)
+	}
+
+	conv = &srule.Convergence{Key: "size", Level: "error", Requires: srule.AtLeastNDistinct, Count: 4}
+	res = MatchConvergence("g", "g(x)", objs, conv, descriptors)
+	probs = convAsProblems(map[string]problem.ProblemLevel{"error": problem.Err}, res)
+	if len(probs) != 1 || len(probs[0].Subject().ObjectIds) != 5 {
+		t.Fatalf("expected one problem for the whole SOG, found %v", probs)
+	}
+	if probs[0].Message != "Group g: Convergence size violation (error) in g(x): 3 distinct values, requires at least 4" {
+		t.Errorf("unexpected message %s", probs[0].Message)
+	}
+}
@@ -0,0 +1,137 @@
+// Under the Apache-2.0 License
+package runner
+
+import (
+	"sync"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
+)
+
+// checkAllCoverage validates the rule coverage implications against the complete object list.
+//
+// Coverage requires knowing every possible counterpart, so this must only run
+// once all the SOG objects exist.
+func checkAllCoverage(
+	workers *pool.Pool,
+	all []*obj.EngineObj,
+	rules []*srule.Rule,
+	results *Results,
+) []*CovProblem {
+	ret := make([]*CovProblem, 0)
+	for p := range checkAllCoverageAsync(workers, all, rules, results) {
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+func checkAllCoverageAsync(
+	workers *pool.Pool,
+	all []*obj.EngineObj,
+	rules []*srule.Rule,
+	results *Results,
+) <-chan *CovProblem {
+	ret := make(chan *CovProblem)
+	go func() {
+		defer close(ret)
+
+		index := obj.NewIndex(all)
+		var wg sync.WaitGroup
+		for _, r := range rules {
+			if len(r.Coverages) <= 0 {
+				continue
+			}
+			matched := make([]*obj.EngineObj, 0)
+			for _, o := range matcher.Candidates(index, r.Matchers) {
+				if ok, _ := matcher.IsMatch(o, r.Matchers); ok {
+					matched = append(matched, o)
+				}
+			}
+			if len(matched) <= 0 {
+				continue
+			}
+			for i := range r.Coverages {
+				c := &r.Coverages[i]
+				workers.Go(&wg, func() {
+					checkCoverage(all, matched, r, c, ret, results)
+				})
+			}
+		}
+		wg.Wait()
+	}()
+	return ret
+}
+
+// checkCoverage ensures each matched object value has enough counterparts, and reports the objects that don't.
+func checkCoverage(
+	all []*obj.EngineObj,
+	matched []*obj.EngineObj,
+	rule *srule.Rule,
+	cov *srule.Coverage,
+	probs chan<- *CovProblem,
+	results *Results,
+) {
+	if results.Halted() != "" {
+		return
+	}
+	counterparts := make(map[*obj.EngineObj]bool)
+	text := make(map[string]int)
+	number := make(map[float64]int)
+	for _, o := range all {
+		if ok, _ := matcher.IsMatch(o, cov.Counterpart); !ok {
+			continue
+		}
+		counterparts[o] = true
+		// Compare the values the same way the SOG shared values do, so the
+		// case of a case-insensitive value doesn't matter.
+		vals := o.SharedValue(cov.Key).Distinct()
+		for _, v := range vals.Text {
+			text[v]++
+		}
+		for _, v := range vals.Number {
+			number[v]++
+		}
+	}
+
+	for _, o := range matched {
+		// An object never covers itself.
+		self := 0
+		if counterparts[o] {
+			self = 1
+		}
+		vals := o.SharedValue(cov.Key).Distinct()
+		uncovered := obj.DescriptorValues{}
+		for _, v := range vals.Text {
+			if text[v]-self < cov.Minimum {
+				uncovered.Text = append(uncovered.Text, v)
+			}
+		}
+		for _, v := range vals.Number {
+			if number[v]-self < cov.Minimum {
+				uncovered.Number = append(uncovered.Number, v)
+			}
+		}
+		check := Check{
+			RuleId:   rule.Id,
+			File:     rule.File,
+			ObjectId: o.Id,
+			Kind:     CoverageCheck,
+			Key:      cov.Key,
+		}
+		if uncovered.Count() > 0 {
+			p := &CovProblem{
+				obj:       o,
+				rule:      rule,
+				cov:       cov,
+				Uncovered: uncovered,
+			}
+			probs <- p
+			check.Failures = []string{covProblemMessage(p)}
+			results.add(check, cov.Level)
+		} else {
+			results.add(check)
+		}
+	}
+}
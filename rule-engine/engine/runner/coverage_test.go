@@ -0,0 +1,149 @@
+// Under the Apache-2.0 License
+package runner_test
+
+import (
+	"strings"
+	"testing"
+)
+
+const coverageRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"rules": [{
+		"id": "c1",
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "source"}]}
+		],
+		"coverages": [{
+			"key": "tag",
+			"level": "error",
+			"counterpart": [
+				{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "test"}]}
+			]
+		}]
+	}]
+}`
+
+func Test_Engine_Coverage(t *testing.T) {
+	doc := `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+		"objects": [
+			{"id": "s1", "sources": [{"ref": "src", "a": "s1"}], "descriptors": [
+				{"key": "data-type", "values": ["source"]},
+				{"key": "tag", "values": ["t1", "t2"]}
+			]},
+			{"id": "s2", "sources": [{"ref": "src", "a": "s2"}], "descriptors": [
+				{"key": "data-type", "values": ["source"]},
+				{"key": "tag", "values": ["t3"]}
+			]},
+			{"id": "x1", "sources": [{"ref": "src", "a": "x1"}], "descriptors": [
+				{"key": "data-type", "values": ["test"]},
+				{"key": "tag", "values": ["t1", "t3"]}
+			]}
+		]
+	}`
+	probs, _ := runEngine(t, engineInput{rules: coverageRules, doc: doc})
+	errs := probs.Problems()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 problem, found %v", errs)
+	}
+	if !strings.HasSuffix(errs[0].Message, "counterparts for t2") {
+		t.Errorf("expected only t2 uncovered, found %s", errs[0].Message)
+	}
+	anchors := make(map[string]bool)
+	for _, s := range errs[0].Sources {
+		if a := s.A(); a != nil {
+			anchors[*a] = true
+		}
+	}
+	if !anchors["s1"] || len(anchors) != 1 {
+		t.Errorf("expected the uncovered object source, found %v", anchors)
+	}
+}
+
+const criticalRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"rules": [{
+		"id": "critical-tested",
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "requirement"}]},
+			{"key": "priority", "type": "containsExactly", "values": [{"type": "equal", "text": "critical"}]}
+		],
+		"coverages": [{
+			"key": "requirement",
+			"level": "error",
+			"counterpart": [
+				{"key": "$test-result", "type": "containsExactly", "values": [{"type": "equal", "text": "pass"}]}
+			]
+		}]
+	}]
+}`
+
+func Test_Engine_TestExecutions(t *testing.T) {
+	doc := `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+		"objects": [
+			{"id": "r1", "sources": [{"ref": "src", "a": "r1"}], "descriptors": [
+				{"key": "data-type", "values": ["requirement"]},
+				{"key": "priority", "values": ["critical"]},
+				{"key": "requirement", "values": ["r1"]}
+			]},
+			{"id": "r2", "sources": [{"ref": "src", "a": "r2"}], "descriptors": [
+				{"key": "data-type", "values": ["requirement"]},
+				{"key": "priority", "values": ["critical"]},
+				{"key": "requirement", "values": ["r2"]}
+			]},
+			{"id": "r3", "sources": [{"ref": "src", "a": "r3"}], "descriptors": [
+				{"key": "data-type", "values": ["requirement"]},
+				{"key": "priority", "values": ["low"]},
+				{"key": "requirement", "values": ["r3"]}
+			]}
+		]
+	}`
+	execs := `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "t", "rep": "r", "loc": "tests", "ver": "1"}],
+		"executions": [
+			{"id": "t1", "sources": [{"ref": "t", "a": "t1"}], "timestamp": "2024-05-01T10:00:00Z", "result": "pass",
+				"descriptors": [{"key": "requirement", "values": ["r1"]}]},
+			{"id": "t2", "sources": [{"ref": "t", "a": "t2"}], "timestamp": "2024-05-01T10:00:00Z", "result": "fail",
+				"descriptors": [{"key": "requirement", "values": ["r2"]}]}
+		]
+	}`
+	probs, _ := runEngine(t, engineInput{rules: criticalRules, doc: doc, execs: []string{execs}})
+	errs := probs.Problems()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 problem, found %v", errs)
+	}
+	if !strings.HasSuffix(errs[0].Message, "counterparts for r2") {
+		t.Errorf("expected r2 without a passing test, found %s", errs[0].Message)
+	}
+}
+
+func Test_Engine_Coverage_CaseInsensitive(t *testing.T) {
+	rules := strings.ReplaceAll(coverageRules, `"key": "tag"`, `"key": "ticket"`)
+	probs, _ := runEngine(t, engineInput{rules: rules, doc: `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+		"objects": [
+			{"id": "s1", "sources": [{"ref": "src", "a": "s1"}], "descriptors": [
+				{"key": "data-type", "values": ["source"]},
+				{"key": "ticket", "values": ["ABC-1", "ABC-2"]}
+			]},
+			{"id": "x1", "sources": [{"ref": "src", "a": "x1"}], "descriptors": [
+				{"key": "data-type", "values": ["test"]},
+				{"key": "ticket", "values": ["abc-1"]}
+			]}
+		]
+	}`})
+	errs := probs.Problems()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 problem, found %v", errs)
+	}
+	if !strings.HasSuffix(errs[0].Message, "counterparts for abc-2") {
+		t.Errorf("expected only abc-2 uncovered, found %s", errs[0].Message)
+	}
+}
@@ -10,18 +10,23 @@ import (
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
-// New turns the documents into engine objects.
+// New turns the documents and test executions into engine objects.
 func New(data *ingest.AllData, config *config.ProjectConfig) EngineRunner {
 	if data == nil {
 		return nil
 	}
 	factory := obj.NewObjFactory(data.OntDescriptors)
-	base := make([]*obj.EngineObj, len(data.Documents.Objects))
-	for i, o := range data.Documents.Objects {
-		base[i] = factory.FromDocument(o)
+	base := make([]*obj.EngineObj, 0, len(data.Documents.Objects))
+	for _, o := range data.Documents.Objects {
+		base = append(base, factory.FromDocument(o))
+	}
+	// Test executions participate as objects with synthetic test descriptors.
+	for _, o := range data.TestExecutions.AsDocuments() {
+		base = append(base, factory.FromDocument(o))
 	}
 	return &engineRunner{
 		factory:  factory,
@@ -30,6 +35,7 @@ func New(data *ingest.AllData, config *config.ProjectConfig) EngineRunner {
 		rules:    data.RuleSets.Rules,
 		base:     base,
 		levelMap: convertLevelMap(config),
+		halt:     config.Halt,
 	}
 }
 
@@ -40,6 +46,7 @@ type engineRunner struct {
 	rules    []*srule.Rule                   // All rules.
 	base     []*obj.EngineObj                // Initial set of document objects.
 	levelMap map[string]problem.ProblemLevel // Maps the rule level to a problem level.
+	halt     *config.HaltPolicy              // When to stop early; may be nil.
 }
 
 func (e *engineRunner) Start(ctx context.Context) (EngineState, problem.ProblemConsumer) {
@@ -47,19 +54,32 @@ func (e *engineRunner) Start(ctx context.Context) (EngineState, problem.ProblemC
 	// each step will only check the assembled SOGs for violations, and there won't
 	// be duplicate rule checks.
 	adder, consumer := problem.Async(ctx)
-	addRuleProblems(adder, e.levelMap, checkAllAgainstRules(e.base, e.rules))
+	// Halting cancels the engine's own pool, not the caller's context, so
+	// the problems still reach the consumer.
+	workers, cancel := pool.From(ctx).WithCancel()
+	results := newResults(e.levelMap, e.halt, cancel)
+	addRuleProblems(adder, e.levelMap, checkAllAgainstRules(workers, e.base, e.rules, results))
 
-	sogs := make([]*sog.SogBuilder, len(e.groups))
-	for i, g := range e.groups {
-		sogs[i] = sog.NewBuilder(g, e.factory)
+	graph := srule.NewGroupGraph(e.groups)
+	strata := make([][]*sog.SogBuilder, 0)
+	for _, stratum := range graph.Strata() {
+		builders := make([]*sog.SogBuilder, len(stratum))
+		for i, g := range stratum {
+			builders[i] = sog.NewBuilder(g, e.factory)
+		}
+		strata = append(strata, builders)
 	}
 
 	return &engineRunnerState{
 		engine:   e,
-		newObj:   e.base,
-		prevObj:  nil,
-		sogs:     sogs,
+		graph:    graph,
+		strata:   strata,
+		produced: make(map[*srule.Group][]*obj.EngineObj),
+		objects:  e.base,
 		problems: adder,
+		results:  results,
+		workers:  workers,
+		cancel:   cancel,
 	}, consumer
 }
 
@@ -0,0 +1,514 @@
+// Test the engine steps.
+//
+// Under the Apache-2.0 License
+package runner_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/stexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+const testOnt = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"descriptors": [
+		{"key": "data-type", "type": "enum", "enum": ["field", "source", "test", "requirement"], "maximumCount": 1},
+		{"key": "priority", "type": "enum", "enum": ["critical", "low"], "maximumCount": 1},
+		{"key": "requirement", "type": "free", "maximumCount": 10, "maximumLength": 100},
+		{"key": "structure", "type": "free", "maximumCount": 1, "maximumLength": 100},
+		{"key": "field-type", "type": "enum", "enum": ["string", "int"], "maximumCount": 1},
+		{"key": "sog-type", "type": "free", "maximumCount": 1, "maximumLength": 100},
+		{"key": "tag", "type": "free", "maximumCount": 10, "maximumLength": 100},
+		{"key": "owner", "type": "free", "maximumCount": 10, "maximumLength": 100},
+		{"key": "ticket", "type": "free", "caseSensitive": false, "maximumCount": 10, "maximumLength": 100}
+	]
+}`
+
+const testRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"groups": [{
+		"id": "g1",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}
+		],
+		"alterations": [
+			{"key": "sog-type", "action": "set", "values": ["s1"]}
+		]
+	}],
+	"rules": [{
+		"id": "r1",
+		"matchingDescriptors": [
+			{"key": "sog-type", "type": "containsExactly", "values": [{"type": "equal", "text": "s1"}]}
+		],
+		"conformities": [{
+			"level": "error",
+			"matcher": {
+				"key": "field-type",
+				"type": "containsExactly",
+				"count": true,
+				"distinct": true,
+				"values": [{"type": "within", "minimum": 1, "maximum": 1}]
+			}
+		}]
+	}]
+}`
+
+func Test_Engine_SogRules(t *testing.T) {
+	t.Run("sog-conforms", func(t *testing.T) {
+		probs, _ := runEngine(t, engineInput{rules: testRules, doc: `{
+			"$schema": "",
+			"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+			"objects": [
+				{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+					{"key": "data-type", "values": ["field"]},
+					{"key": "sog-type", "values": ["field"]},
+					{"key": "structure", "values": ["x"]},
+					{"key": "field-type", "values": ["string"]}
+				]},
+				{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+					{"key": "data-type", "values": ["field"]},
+					{"key": "sog-type", "values": ["field"]},
+					{"key": "structure", "values": ["x"]},
+					{"key": "field-type", "values": ["string"]}
+				]}
+			]
+		}`})
+		if probs.HasProblems() {
+			t.Errorf("unexpected problems: %v", probs.Problems())
+		}
+	})
+	t.Run("sog-multiple", func(t *testing.T) {
+		// Each group runs once, so its SOG objects never join its own SOGs.
+		probs, results := runEngine(t, engineInput{rules: testRules, doc: `{
+			"$schema": "",
+			"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+			"objects": [
+				{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+					{"key": "data-type", "values": ["field"]},
+					{"key": "sog-type", "values": ["field"]},
+					{"key": "structure", "values": ["x"]},
+					{"key": "field-type", "values": ["string"]}
+				]},
+				{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+					{"key": "data-type", "values": ["field"]},
+					{"key": "sog-type", "values": ["field"]},
+					{"key": "structure", "values": ["y"]},
+					{"key": "field-type", "values": ["int"]}
+				]}
+			]
+		}`})
+		if probs.HasProblems() {
+			t.Errorf("unexpected problems: %v", probs.Problems())
+		}
+		if passed, failed := results.Counts(); passed != 2 || failed != 0 {
+			t.Errorf("expected 2 passed SOG checks, found %d passed and %d failed", passed, failed)
+		}
+	})
+	t.Run("sog-violates", func(t *testing.T) {
+		probs, _ := runEngine(t, engineInput{rules: testRules, doc: `{
+			"$schema": "",
+			"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+			"objects": [
+				{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+					{"key": "data-type", "values": ["field"]},
+					{"key": "sog-type", "values": ["field"]},
+					{"key": "structure", "values": ["x"]},
+					{"key": "field-type", "values": ["string"]}
+				]},
+				{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+					{"key": "data-type", "values": ["field"]},
+					{"key": "sog-type", "values": ["field"]},
+					{"key": "structure", "values": ["x"]},
+					{"key": "field-type", "values": ["int"]}
+				]}
+			]
+		}`})
+		errs := probs.Problems()
+		if len(errs) != 1 {
+			t.Fatalf("expected 1 problem, found %v", errs)
+		}
+		anchors := make(map[string]bool)
+		for _, s := range errs[0].Sources {
+			if a := s.A(); a != nil {
+				anchors[*a] = true
+			}
+		}
+		if !anchors["1"] || !anchors["2"] {
+			t.Errorf("expected member sources in the problem, found %v", anchors)
+		}
+	})
+}
+
+const fieldRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"rules": [{
+		"id": "f1",
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}
+		],
+		"conformities": [{
+			"level": "error",
+			"matcher": {"key": "field-type", "type": "containsExactly", "values": [{"type": "equal", "text": "string"}]}
+		}]
+	}]
+}`
+
+func Test_Engine_Results(t *testing.T) {
+	probs, results := runEngine(t, engineInput{rules: fieldRules, doc: `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+		"objects": [
+			{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "field-type", "values": ["string"]}
+			]},
+			{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "field-type", "values": ["int"]}
+			]},
+			{"id": "c", "sources": [{"ref": "src", "a": "3"}], "descriptors": [
+				{"key": "data-type", "values": ["source"]}
+			]}
+		]
+	}`})
+	if len(probs.Problems()) != 1 {
+		t.Fatalf("expected 1 problem, found %v", probs.Problems())
+	}
+	passed, failed := results.Counts()
+	if passed != 1 || failed != 1 {
+		t.Errorf("expected 1 passed and 1 failed check, found %d passed and %d failed", passed, failed)
+	}
+	checks := results.Checks()
+	if len(checks) != 2 || checks[0].ObjectId != "a" || checks[1].ObjectId != "b" {
+		t.Fatalf("expected checks for a and b, found %v", checks)
+	}
+	for _, c := range checks {
+		if c.RuleId != "f1" || c.File != "rules.json" || c.Kind != runner.ConformityCheck {
+			t.Errorf("bad check: %v", c)
+		}
+	}
+	if c := checks[1]; c.Level != probs.Problems()[0].Level || len(c.Failures) != 1 || !strings.HasPrefix(c.Failures[0], "Mismatch for b") {
+		t.Errorf("bad failed check: %v", c)
+	}
+}
+
+// engineInput is the input of a single test engine run, which always uses the test ontology.
+type engineInput struct {
+	rules string
+	doc   string
+	execs []string
+	halt  *config.HaltPolicy
+}
+
+// runEngine runs the engine over the input until it has no more steps.
+func runEngine(t *testing.T, in engineInput) (*problem.ProblemSet, *runner.Results) {
+	data := loadEngineData(t, in)
+	ctx := context.Background()
+	engine := runner.New(data, engineConfig(in.halt))
+	state, pReader := engine.Start(ctx)
+	for state.Step() {
+	}
+	state.Stop()
+	return pReader.Read(ctx), state.Results()
+}
+
+// loadEngineData parses the input into the data read by the engine.
+func loadEngineData(t *testing.T, in engineInput) *ingest.AllData {
+	ontSrc, err := ingest.ParseOntology(strings.NewReader(testOnt), "ont")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ruleSrc, err := ingest.ParseRule(strings.NewReader(in.rules), "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	docSrc, err := ingest.ParseDocuments(strings.NewReader(in.doc), "doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := &ingest.AllData{
+		OntDescriptors: sont.New(),
+		RuleSets:       srule.New(),
+		Documents:      sdoc.New(),
+	}
+	data.OntDescriptors.Add(ontSrc)
+	data.RuleSets.AddFile(ruleSrc, sources.NewInputFile("rules.json", nil))
+	data.Documents.Add(docSrc)
+	if len(data.RuleSets.Groups) > 0 {
+		data.OntDescriptors.Add(sont.MemberOntology())
+	}
+	if len(in.execs) > 0 {
+		data.TestExecutions = stexec.New()
+		data.OntDescriptors.Add(stexec.Ontology())
+		for i, e := range in.execs {
+			execSrc, err := ingest.ParseTestExecution(strings.NewReader(e), "exec-"+strconv.Itoa(i))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data.TestExecutions.Add(execSrc)
+		}
+	}
+	if data.Problems().HasProblems() {
+		t.Fatal(data.Problems().Problems())
+	}
+	return data
+}
+
+func engineConfig(halt *config.HaltPolicy) *config.ProjectConfig {
+	return &config.ProjectConfig{
+		LevelMap:     map[string]int{"info": 1, "warn": 2, "error": 3},
+		InfoLevel:    1,
+		WarningLevel: 2,
+		ErrorLevel:   3,
+		Halt:         halt,
+	}
+}
+
+const groupConformityRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"groups": [{
+		"id": "g1",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}
+		],
+		"alterations": [
+			{"key": "sog-type", "action": "set", "values": ["s1"]}
+		],
+		"conformities": [{
+			"level": "error",
+			"matcher": {
+				"key": "field-type",
+				"type": "containsExactly",
+				"count": true,
+				"distinct": true,
+				"values": [{"type": "within", "minimum": 1, "maximum": 1}]
+			}
+		}]
+	}]
+}`
+
+func Test_Engine_GroupConformities(t *testing.T) {
+	probs, results := runEngine(t, engineInput{rules: groupConformityRules, doc: `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+		"objects": [
+			{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "structure", "values": ["x"]},
+				{"key": "field-type", "values": ["string"]}
+			]},
+			{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "structure", "values": ["x"]},
+				{"key": "field-type", "values": ["int"]}
+			]},
+			{"id": "c", "sources": [{"ref": "src", "a": "3"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "structure", "values": ["y"]},
+				{"key": "field-type", "values": ["int"]}
+			]}
+		]
+	}`})
+	errs := probs.Problems()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 problem, found %v", errs)
+	}
+	s := errs[0].Subject()
+	if s == nil || s.GroupId != "g1" || s.SogId != "g1(structure=x)" {
+		t.Errorf("expected the group and SOG subject, found %v", s)
+	}
+	anchors := make(map[string]bool)
+	for _, src := range errs[0].Sources {
+		if a := src.A(); a != nil {
+			anchors[*a] = true
+		}
+	}
+	if !anchors["1"] || !anchors["2"] || anchors["3"] {
+		t.Errorf("expected the member sources in the problem, found %v", anchors)
+	}
+
+	passed, failed := results.Counts()
+	if passed != 1 || failed != 1 {
+		t.Errorf("expected 1 passed and 1 failed check, found %d passed and %d failed", passed, failed)
+	}
+	for _, c := range results.Checks() {
+		if c.GroupId != "g1" || c.RuleId != "" || c.Kind != runner.ConformityCheck {
+			t.Errorf("bad check: %v", c)
+		}
+	}
+}
+
+// inheritedRules has a structure group that matches the field SOG objects
+// through their members' data-type, which no alteration sets.  The structure
+// group sets its own data-type, so the field group can't match its SOG objects.
+const inheritedRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"groups": [{
+		"id": "fields",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]},
+			{"key": "field-type", "type": "containsSome", "values": [{"type": "pattern", "pattern": "."}]}
+		]
+	}, {
+		"id": "structures",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]}
+		],
+		"alterations": [{"key": "data-type", "action": "set", "values": ["source"]}],
+		"conformities": [{
+			"level": "error",
+			"matcher": {"key": "$member-count", "type": "containsSome", "values": [{"type": "within", "minimum": 4, "maximum": 4}]}
+		}]
+	}]
+}`
+
+const inheritedDoc = `{
+	"$schema": "",
+	"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+	"objects": [
+		{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+			{"key": "data-type", "values": ["field"]},
+			{"key": "structure", "values": ["x"]},
+			{"key": "field-type", "values": ["string"]}
+		]},
+		{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+			{"key": "data-type", "values": ["field"]},
+			{"key": "structure", "values": ["x"]},
+			{"key": "field-type", "values": ["int"]}
+		]},
+		{"id": "c", "sources": [{"ref": "src", "a": "3"}], "descriptors": [
+			{"key": "data-type", "values": ["field"]},
+			{"key": "structure", "values": ["x"]}
+		]}
+	]
+}`
+
+func Test_Engine_InheritedGroupJoin(t *testing.T) {
+	probs, results := runEngine(t, engineInput{rules: inheritedRules, doc: inheritedDoc})
+	// The structure SOG has the three fields, and the SOG of the two typed fields.
+	if probs.HasProblems() {
+		t.Errorf("unexpected problems: %v", probs.Problems())
+	}
+	checks := results.Checks()
+	if len(checks) != 1 || checks[0].GroupId != "structures" || len(checks[0].Failures) != 0 {
+		t.Errorf("expected one passed structures check, found %v", checks)
+	}
+}
+
+func Test_Engine_Candidates(t *testing.T) {
+	data := loadEngineData(t, engineInput{rules: inheritedRules, doc: inheritedDoc})
+	ctx := context.Background()
+	state, pReader := runner.New(data, engineConfig(nil)).Start(ctx)
+	for state.Step() {
+	}
+	state.Stop()
+	if probs := pReader.Read(ctx); probs.HasProblems() {
+		t.Errorf("unexpected problems: %v", probs.Problems())
+	}
+	// The fields group only sees the documents; the structures group also sees the field SOG.
+	expected := map[string]int{"fields": 3, "structures": 4}
+	for _, g := range data.RuleSets.Groups {
+		if c := state.Candidates(g); len(c) != expected[g.Id] {
+			t.Errorf("group %s: expected %d candidates, found %d", g.Id, expected[g.Id], len(c))
+		}
+	}
+}
+
+// ownedRules has an owned group that matches the field SOG objects through the
+// owner that only some of their members have.
+func ownedRules(alterations string) string {
+	return `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"groups": [{
+		"id": "fields",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]}
+		]
+	}, {
+		"id": "owned",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "owner", "type": "containsSome", "values": [{"type": "equal", "text": "alice"}]}
+		],
+		"alterations": [` + alterations + `],
+		"conformities": [{
+			"level": "error",
+			"matcher": {"key": "$member-count", "type": "containsSome", "values": [{"type": "within", "minimum": 2, "maximum": 2}]}
+		}]
+	}]
+}`
+}
+
+const ownedDoc = `{
+	"$schema": "",
+	"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+	"objects": [
+		{"id": "a", "sources": [{"ref": "src", "a": "1"}], "descriptors": [
+			{"key": "data-type", "values": ["field"]},
+			{"key": "structure", "values": ["x"]},
+			{"key": "owner", "values": ["alice"]}
+		]},
+		{"id": "b", "sources": [{"ref": "src", "a": "2"}], "descriptors": [
+			{"key": "data-type", "values": ["field"]},
+			{"key": "structure", "values": ["x"]}
+		]}
+	]
+}`
+
+func Test_Engine_InheritedOptionalKey(t *testing.T) {
+	t.Run("ordered", func(t *testing.T) {
+		// The owned SOG has the owned field, and the field SOG, which has the owner from one member.
+		probs, results := runEngine(t, engineInput{
+			rules: ownedRules(`{"key": "data-type", "action": "set", "values": ["source"]}`),
+			doc:   ownedDoc,
+		})
+		if probs.HasProblems() {
+			t.Errorf("unexpected problems: %v", probs.Problems())
+		}
+		checks := results.Checks()
+		if len(checks) != 1 || checks[0].GroupId != "owned" || len(checks[0].Failures) != 0 {
+			t.Errorf("expected one passed owned check, found %v", checks)
+		}
+	})
+
+	t.Run("mutual", func(t *testing.T) {
+		// Each group may match the other's SOG objects, so neither sees them, with a warning for each.
+		probs, _ := runEngine(t, engineInput{rules: ownedRules(""), doc: ownedDoc})
+		ignored := make([]string, 0)
+		for _, p := range probs.ProblemsAt(problem.Warn) {
+			if strings.HasSuffix(p.Message, "never sees it") {
+				ignored = append(ignored, p.Message)
+			}
+		}
+		if len(ignored) != 2 {
+			t.Fatalf("expected two ignored SOG warnings, found %v", probs.Problems())
+		}
+		for i, prefix := range []string{"group fields: matchers match owned(", "group owned: matchers match fields("} {
+			if !strings.HasPrefix(ignored[i], prefix) {
+				t.Errorf("unexpected warning: %s", ignored[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,10 @@
+// Exposes the unexported engine parts to the runner_test package.
+//
+// Under the Apache-2.0 License
+package runner
+
+var NewResults = newResults
+
+func (r *Results) Add(c Check, failedLevels ...string) {
+	r.add(c, failedLevels...)
+}
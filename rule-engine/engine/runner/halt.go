@@ -0,0 +1,62 @@
+// Under the Apache-2.0 License
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// haltTracker accumulates the failed checks, and decides when the halting policy stops the engine.
+//
+// The tracker is only used while holding the results lock.
+type haltTracker struct {
+	policy *config.HaltPolicy
+	cancel context.CancelFunc // Cancels the engine's outstanding work.
+	errors int
+	levels map[string]int
+	reason string
+}
+
+func newHaltTracker(policy *config.HaltPolicy, cancel context.CancelFunc) *haltTracker {
+	if policy == nil {
+		return nil
+	}
+	return &haltTracker{policy: policy, cancel: cancel, levels: make(map[string]int)}
+}
+
+// observe counts the failed check, and records the first reason to halt.
+//
+// Once halted, this cancels the engine's work that hasn't started.
+func (h *haltTracker) observe(c Check, failedLevels []string) {
+	if h == nil || c.Passed() || h.reason != "" {
+		return
+	}
+	h.reason = h.haltReason(c, failedLevels)
+	if h.reason != "" && h.cancel != nil {
+		h.cancel()
+	}
+}
+
+func (h *haltTracker) haltReason(c Check, failedLevels []string) string {
+	id := c.RuleId + c.GroupId
+	if slices.Contains(h.policy.Rules, id) {
+		return fmt.Sprintf("%s %s failed for %s", c.Kind, id, c.ObjectId)
+	}
+	if c.Level == problem.Err {
+		h.errors++
+		if h.policy.MaxErrors > 0 && h.errors >= h.policy.MaxErrors {
+			return fmt.Sprintf("reached %d failed checks at the error level", h.errors)
+		}
+	}
+	for _, l := range failedLevels {
+		h.levels[l]++
+		if limit := h.policy.MaxLevels[l]; limit > 0 && h.levels[l] >= limit {
+			return fmt.Sprintf("reached %d failed '%s' implications", h.levels[l], l)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+// Under the Apache-2.0 License
+package runner_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// haltRules uses a level missing from the level map for the group, so its failures are errors.
+const haltRules = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"groups": [{
+		"id": "g1",
+		"sharedValues": ["structure"],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}
+		],
+		"conformities": [{
+			"level": "blocker",
+			"matcher": {
+				"key": "field-type",
+				"type": "containsExactly",
+				"count": true,
+				"distinct": true,
+				"values": [{"type": "within", "minimum": 1, "maximum": 1}]
+			}
+		}]
+	}],
+	"rules": [{
+		"id": "f1",
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}
+		],
+		"conformities": [{
+			"level": "warn",
+			"matcher": {"key": "field-type", "type": "containsExactly", "values": [{"type": "equal", "text": "string"}]}
+		}]
+	}]
+}`
+
+func Test_Engine_Halt(t *testing.T) {
+	doc := `{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "src", "rep": "r", "loc": "l"}],
+		"objects": [
+			{"id": "a", "sources": [{"ref": "src"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "structure", "values": ["x"]},
+				{"key": "field-type", "values": ["string"]}
+			]},
+			{"id": "b", "sources": [{"ref": "src"}], "descriptors": [
+				{"key": "data-type", "values": ["field"]},
+				{"key": "structure", "values": ["x"]},
+				{"key": "field-type", "values": ["int"]}
+			]}
+		]
+	}`
+	for name, tc := range map[string]struct {
+		halt   *config.HaltPolicy
+		halted string
+		groups bool
+	}{
+		"none":        {halt: nil, groups: true},
+		"unreached":   {halt: &config.HaltPolicy{MaxErrors: 2, MaxLevels: map[string]int{"warn": 2}}, groups: true},
+		"rule":        {halt: &config.HaltPolicy{Rules: []string{"f1"}}, halted: "conformity f1 failed for b"},
+		"level":       {halt: &config.HaltPolicy{MaxLevels: map[string]int{"warn": 1}}, halted: "reached 1 failed 'warn' implications"},
+		"max-errors":  {halt: &config.HaltPolicy{MaxErrors: 1}, halted: "reached 1 failed checks at the error level", groups: true},
+		"group-rules": {halt: &config.HaltPolicy{Rules: []string{"g1"}}, halted: "conformity g1 failed for g1(structure=x)", groups: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			probs, results := runEngine(t, engineInput{rules: haltRules, doc: doc, halt: tc.halt})
+			if h := results.Halted(); h != tc.halted {
+				t.Errorf("expected halted '%s', found '%s'", tc.halted, h)
+			}
+			halted := false
+			for _, p := range probs.Errors() {
+				if p.Message == "Engine halted: "+tc.halted {
+					halted = true
+				}
+			}
+			if halted != (tc.halted != "") {
+				t.Errorf("expected halted problem %v, found %v", tc.halted != "", probs.Problems())
+			}
+			groups := false
+			for _, c := range results.Checks() {
+				if c.GroupId == "g1" {
+					groups = true
+				}
+			}
+			if groups != tc.groups {
+				t.Errorf("expected group checks %v, found %v", tc.groups, results.Checks())
+			}
+		})
+	}
+}
+
+func Test_Results_HaltCancelsWork(t *testing.T) {
+	workers, cancel := pool.New(context.Background(), 1).WithCancel()
+	defer cancel()
+	results := runner.NewResults(
+		map[string]problem.ProblemLevel{}, &config.HaltPolicy{Rules: []string{"g1"}}, cancel)
+	var wg sync.WaitGroup
+	ran := 0
+	if !workers.Go(&wg, func() { ran++ }) {
+		t.Fatal("expected the pool to accept work before the halt")
+	}
+	wg.Wait()
+
+	results.Add(runner.Check{GroupId: "g1", ObjectId: "a", Kind: runner.ConformityCheck, Failures: []string{"bad"}})
+	if results.Halted() == "" {
+		t.Fatal("expected the failed check to halt the engine")
+	}
+	if workers.Go(&wg, func() { ran++ }) {
+		t.Error("expected the pool to drop work submitted after the halt")
+	}
+	wg.Wait()
+	if ran != 1 {
+		t.Errorf("expected only the work before the halt to run, found %d runs", ran)
+	}
+	if workers.Err() == nil {
+		t.Error("expected the halt to cancel the pool")
+	}
+}
@@ -4,6 +4,8 @@ package runner
 import (
 	"context"
 
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
@@ -16,4 +18,15 @@ type EngineState interface {
 	// Step returns 'false' if it encounters an end state.
 	Step() bool
 	Stop()
+
+	// Results returns the checks performed so far, including those that passed.
+	Results() *Results
+
+	// Objects returns the document and SOG objects created so far.
+	Objects() []*obj.EngineObj
+
+	// Candidates returns the objects the group builds its SOG instances from.
+	//
+	// These are the document objects and the SOG objects of the groups it depends on.
+	Candidates(group *srule.Group) []*obj.EngineObj
 }
@@ -26,10 +26,65 @@ type MemberValues struct {
 	Number  []float64
 }
 
+// GroupProblem is a SOG object that does not conform to one of its group's conformities.
+type GroupProblem struct {
+	obj        *obj.EngineObj
+	group      *srule.Group
+	matcher    *srule.LeveledMatcher
+	violations []matcher.MatcherMismatch
+}
+
+// ConvProblem is a convergence violation within one SOG instance.
+//
+// Dominant contains the values the members should have, when the requirement
+// has them, and Mismatched only the deviating members.  A violation of the SOG
+// as a whole, such as too few distinct values, has no mismatched members.
 type ConvProblem struct {
 	GroupId    string
+	SogId      string
+	Members    []*obj.EngineObj
+	Dominant   *MemberValues
 	Mismatched []MemberValues
 	Conv       *srule.Convergence
+	Reason     string // Why the members fail, for requirements that need more than the values to explain.
+}
+
+// ConvOutlier is a single deviating member of a convergence violation.
+type ConvOutlier struct {
+	*ConvProblem
+	Member *obj.EngineObj
+	Values MemberValues
+}
+
+type CovProblem struct {
+	obj       *obj.EngineObj
+	rule      *srule.Rule
+	cov       *srule.Coverage
+	Uncovered obj.DescriptorValues
+}
+
+func (p *RuleProblem) Subject() problem.Subject {
+	return problem.Subject{RuleId: p.rule.Id, ObjectIds: []string{p.obj.Id}}
+}
+
+func (p *GroupProblem) Subject() problem.Subject {
+	return problem.Subject{GroupId: p.group.Id, SogId: p.obj.Id, ObjectIds: []string{p.obj.Id}}
+}
+
+func (p *ConvProblem) Subject() problem.Subject {
+	ids := make([]string, len(p.Members))
+	for i, o := range p.Members {
+		ids[i] = o.Id
+	}
+	return problem.Subject{GroupId: p.GroupId, SogId: p.SogId, ObjectIds: ids}
+}
+
+func (p *ConvOutlier) Subject() problem.Subject {
+	return problem.Subject{GroupId: p.GroupId, SogId: p.SogId, ObjectIds: []string{p.Member.Id}}
+}
+
+func (p *CovProblem) Subject() problem.Subject {
+	return problem.Subject{RuleId: p.rule.Id, ObjectIds: []string{p.obj.Id}}
 }
 
 func addRuleProblems(
@@ -42,12 +97,22 @@ func addRuleProblems(
 	}
 }
 
+func addCoverageProblems(
+	adder problem.Adder,
+	levelMap map[string]problem.ProblemLevel,
+	probs []*CovProblem,
+) {
+	for _, p := range probs {
+		adder.Add(covAsProblem(levelMap, p))
+	}
+}
+
 func ruleAsProblem(
 	levelMap map[string]problem.ProblemLevel,
 	prob *RuleProblem,
 ) problem.Problem {
 	sources := make([]sources.Source, 0)
-	sources = append(sources, prob.obj.Source.Source...)
+	sources = append(sources, prob.obj.Source.AllSources()...)
 	sources = append(sources, prob.rule.Sources...)
 	sources = append(sources, prob.matcher.Sources...)
 
@@ -73,6 +138,38 @@ func ruleProblemMessage(prob *RuleProblem) string {
 	)
 }
 
+// groupAsProblem turns the group conformity violation into a problem with the SOG member sources.
+func groupAsProblem(
+	levelMap map[string]problem.ProblemLevel,
+	prob *GroupProblem,
+) problem.Problem {
+	sources := make([]sources.Source, 0)
+	sources = append(sources, prob.obj.Source.AllSources()...)
+	sources = append(sources, prob.group.Sources...)
+	sources = append(sources, prob.matcher.Sources...)
+
+	return problem.Problem{
+		Level:   errLevel(prob.matcher.Level, levelMap),
+		Message: groupProblemMessage(prob),
+		Sources: sources,
+		Context: prob,
+	}
+}
+
+func groupProblemMessage(prob *GroupProblem) string {
+	matchers := make([]string, len(prob.violations))
+	for i, v := range prob.violations {
+		matchers[i] = v.String()
+	}
+	return fmt.Sprintf(
+		"Group %s conformity violation (%s) for %s: %s",
+		prob.group.Id,
+		prob.matcher.Level,
+		prob.obj.String(),
+		strings.Join(matchers, ", "),
+	)
+}
+
 func errLevel(
 	level string,
 	levelMap map[string]problem.ProblemLevel,
@@ -83,50 +180,135 @@ func errLevel(
 	return problem.Err
 }
 
-func convAsProblem(
+func covAsProblem(
 	levelMap map[string]problem.ProblemLevel,
-	prob *ConvProblem,
+	prob *CovProblem,
 ) problem.Problem {
 	sources := make([]sources.Source, 0)
+	sources = append(sources, prob.obj.Source.AllSources()...)
+	sources = append(sources, prob.rule.Sources...)
+	sources = append(sources, prob.cov.Sources...)
+
+	return problem.Problem{
+		Level:   errLevel(prob.cov.Level, levelMap),
+		Message: covProblemMessage(prob),
+		Sources: sources,
+		Context: prob,
+	}
+}
+
+func covProblemMessage(prob *CovProblem) string {
+	values := make([]string, 0, prob.Uncovered.Count())
+	for _, v := range prob.Uncovered.Number {
+		values = append(values, strconv.FormatFloat(v, 'f', 4, 64))
+	}
+	values = append(values, prob.Uncovered.Text...)
+	return fmt.Sprintf(
+		"Rule %s: Coverage %s violation (%s) for %s: fewer than %d counterparts for %s",
+		prob.rule.Id,
+		prob.cov.Key,
+		prob.cov.Level,
+		prob.obj.String(),
+		prob.cov.Minimum,
+		strings.Join(values, ", "),
+	)
+}
+
+// convAsProblems turns the violation into one problem for each deviating member.
+//
+// Each problem has the member's own sources.  A violation of the SOG as a whole
+// is a single problem with the sources of all the members.
+func convAsProblems(
+	levelMap map[string]problem.ProblemLevel,
+	prob *ConvProblem,
+) []problem.Problem {
+	level := errLevel(prob.Conv.Level, levelMap)
+	ret := make([]problem.Problem, 0)
 	for _, m := range prob.Mismatched {
 		for _, o := range m.Members {
-			sources = append(sources, o.Source.Source...)
+			outlier := &ConvOutlier{ConvProblem: prob, Member: o, Values: m}
+			src := make([]sources.Source, 0)
+			src = append(src, o.Source.AllSources()...)
+			src = append(src, prob.Conv.Sources...)
+			ret = append(ret, problem.Problem{
+				Level:   level,
+				Message: convOutlierMessage(outlier),
+				Sources: src,
+				Context: outlier,
+			})
 		}
 	}
-	sources = append(sources, prob.Conv.Sources...)
+	if len(ret) > 0 {
+		return ret
+	}
 
-	return problem.Problem{
-		Level:   errLevel(prob.Conv.Level, levelMap),
+	src := make([]sources.Source, 0)
+	for _, o := range prob.Members {
+		src = append(src, o.Source.AllSources()...)
+	}
+	src = append(src, prob.Conv.Sources...)
+	return []problem.Problem{{
+		Level:   level,
 		Message: convProblemMessage(prob),
-		Sources: sources,
+		Sources: src,
 		Context: prob,
-	}
+	}}
 }
 
 func convProblemMessage(prob *ConvProblem) string {
-	groups := make([]string, len(prob.Mismatched))
-	for i, o := range prob.Mismatched {
-		groups[i] = o.String()
-	}
-
 	return fmt.Sprintf(
-		"Group %s: Convergence %s violation (%s); %s",
+		"Group %s: Convergence %s violation (%s) in %s: %s",
+		prob.GroupId,
+		prob.Conv.Key,
+		prob.Conv.Level,
+		prob.SogId,
+		prob.Reason,
+	)
+}
+
+func convOutlierMessage(prob *ConvOutlier) string {
+	ret := fmt.Sprintf(
+		"Group %s: Convergence %s violation (%s) in %s: %s has (%s)",
 		prob.GroupId,
 		prob.Conv.Key,
 		prob.Conv.Level,
-		strings.Join(groups, ", "),
+		prob.SogId,
+		prob.Member.Id,
+		prob.Values.valueText(),
 	)
+	if prob.Dominant != nil {
+		ret += fmt.Sprintf(
+			" instead of (%s) like %s",
+			prob.Dominant.valueText(),
+			memberCount(len(prob.Dominant.Members)),
+		)
+	}
+	if prob.Reason != "" {
+		ret += "; " + prob.Reason
+	}
+	return ret
+}
+
+func memberCount(n int) string {
+	if n == 1 {
+		return "1 member"
+	}
+	return strconv.Itoa(n) + " members"
 }
 
 func (m MemberValues) String() string {
+	objs := make([]string, len(m.Members))
+	for i, o := range m.Members {
+		objs[i] = o.String()
+	}
+	return fmt.Sprintf("%s (contain %s)", strings.Join(objs, ", "), m.valueText())
+}
+
+func (m MemberValues) valueText() string {
 	values := make([]string, 0, len(m.Number)+len(m.Text))
 	for _, v := range m.Number {
 		values = append(values, strconv.FormatFloat(v, 'f', 4, 64))
 	}
 	values = append(values, m.Text...)
-	objs := make([]string, len(m.Members))
-	for i, o := range m.Members {
-		objs[i] = o.String()
-	}
-	return fmt.Sprintf("%s (contain %s)", strings.Join(objs, ", "), strings.Join(values, ", "))
+	return strings.Join(values, ", ")
 }
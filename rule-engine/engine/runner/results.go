@@ -0,0 +1,132 @@
+// Under the Apache-2.0 License
+package runner
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// Implication kinds evaluated by the engine.
+const (
+	ConformityCheck  = "conformity"
+	CoverageCheck    = "coverage"
+	ConvergenceCheck = "convergence"
+)
+
+// Check records a single evaluation of a rule or group against an object, whether it passed or failed.
+//
+// A rule's conformities are evaluated together, so they produce one check per
+// matched object.  Each coverage and convergence produces its own check.
+type Check struct {
+	RuleId   string
+	GroupId  string
+	File     string
+	ObjectId string
+	Kind     string
+	Key      string // Coverage or convergence key.
+	Level    problem.ProblemLevel
+	Failures []string
+}
+
+func (c Check) Passed() bool {
+	return len(c.Failures) <= 0
+}
+
+// Results collects the checks performed during the engine run.
+type Results struct {
+	lock     sync.Mutex
+	checks   []Check
+	levelMap map[string]problem.ProblemLevel
+	halt     *haltTracker
+}
+
+// newResults creates the results, which call cancel once the halting policy stops the engine.
+func newResults(
+	levelMap map[string]problem.ProblemLevel,
+	halt *config.HaltPolicy,
+	cancel context.CancelFunc,
+) *Results {
+	return &Results{checks: make([]Check, 0), levelMap: levelMap, halt: newHaltTracker(halt, cancel)}
+}
+
+// add records the check.  The check's problem level is the highest of the failed implication levels.
+func (r *Results) add(c Check, failedLevels ...string) {
+	if r == nil {
+		return
+	}
+	for _, l := range failedLevels {
+		if pl := errLevel(l, r.levelMap); pl > c.Level {
+			c.Level = pl
+		}
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.checks = append(r.checks, c)
+	r.halt.observe(c, failedLevels)
+}
+
+// Halted returns why the halting policy stopped the engine, or an empty string if it did not.
+//
+// Once halted, the engine skips the outstanding evaluations, so the checks are
+// only a partial result.
+func (r *Results) Halted() string {
+	if r == nil {
+		return ""
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.halt == nil {
+		return ""
+	}
+	return r.halt.reason
+}
+
+// Checks returns a copy of the recorded checks, in a stable order.
+func (r *Results) Checks() []Check {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	ret := make([]Check, len(r.checks))
+	copy(ret, r.checks)
+	r.lock.Unlock()
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.RuleId+a.GroupId != b.RuleId+b.GroupId {
+			return a.RuleId+a.GroupId < b.RuleId+b.GroupId
+		}
+		if a.ObjectId != b.ObjectId {
+			return a.ObjectId < b.ObjectId
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Key < b.Key
+	})
+	return ret
+}
+
+// Counts returns the number of passed and failed checks.
+func (r *Results) Counts() (passed int, failed int) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, c := range r.checks {
+		if c.Passed() {
+			passed++
+		} else {
+			failed++
+		}
+	}
+	return
+}
@@ -7,35 +7,40 @@ import (
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 )
 
 func checkAllAgainstRules(
+	workers *pool.Pool,
 	all []*obj.EngineObj,
 	rules []*srule.Rule,
+	results *Results,
 ) []*RuleProblem {
 	ret := make([]*RuleProblem, 0)
-	for p := range checkAllAgainstRulesAsync(all, rules) {
+	for p := range checkAllAgainstRulesAsync(workers, all, rules, results) {
 		ret = append(ret, p)
 	}
 	return ret
 }
 
 func checkAllAgainstRulesAsync(
+	workers *pool.Pool,
 	all []*obj.EngineObj,
 	rules []*srule.Rule,
+	results *Results,
 ) <-chan *RuleProblem {
 	ret := make(chan *RuleProblem)
 	go func() {
 		defer close(ret)
 
+		// Only the objects that may match a rule need checking against it.
+		index := obj.NewIndex(all)
 		var wg sync.WaitGroup
-		for _, o := range all {
-			for _, r := range rules {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-					checkAgainstRule(o, r, ret)
-				}()
+		for _, r := range rules {
+			for _, o := range matcher.Candidates(index, r.Matchers) {
+				workers.Go(&wg, func() {
+					checkAgainstRule(o, r, ret, results)
+				})
 			}
 		}
 		wg.Wait()
@@ -44,22 +49,90 @@ func checkAllAgainstRulesAsync(
 }
 
 // checkAgainstRule validates the object against the rule, and adds violations to the problem set.
+//
+// Each object matching the rule records a check, so the passed evaluations are known too.
 func checkAgainstRule(
 	o *obj.EngineObj,
 	rule *srule.Rule,
 	probs chan<- *RuleProblem,
+	results *Results,
 ) {
+	if results.Halted() != "" {
+		return
+	}
 	if ok, _ := matcher.IsMatch(o, rule.Matchers); ok {
-		// The object must conform.
-		for _, c := range rule.Conformities {
-			if matches, errs := matcher.IsMatch(o, c.Matchers); !matches {
-				probs <- &RuleProblem{
-					obj:        o,
-					rule:       rule,
-					matcher:    &c,
-					violations: errs,
-				}
+		if len(rule.Conformities) <= 0 {
+			return
+		}
+		check := Check{
+			RuleId:   rule.Id,
+			File:     rule.File,
+			ObjectId: o.Id,
+			Kind:     ConformityCheck,
+		}
+		failedLevels := checkConformities(o, rule.Conformities, &check, func(c *srule.LeveledMatcher, errs []matcher.MatcherMismatch) {
+			probs <- &RuleProblem{
+				obj:        o,
+				rule:       rule,
+				matcher:    c,
+				violations: errs,
+			}
+		})
+		results.add(check, failedLevels...)
+	}
+}
+
+// checkGroupConformities validates the sealed SOG object against its group's conformities.
+//
+// Every SOG object of the group must conform, so this records a check when the group has conformities.
+func checkGroupConformities(
+	o *obj.EngineObj,
+	group *srule.Group,
+	results *Results,
+) []*GroupProblem {
+	ret := make([]*GroupProblem, 0)
+	if len(group.Conformities) <= 0 {
+		return ret
+	}
+	check := Check{
+		GroupId:  group.Id,
+		File:     group.File,
+		ObjectId: o.Id,
+		Kind:     ConformityCheck,
+	}
+	failedLevels := checkConformities(o, group.Conformities, &check, func(c *srule.LeveledMatcher, errs []matcher.MatcherMismatch) {
+		ret = append(ret, &GroupProblem{
+			obj:        o,
+			group:      group,
+			matcher:    c,
+			violations: errs,
+		})
+	})
+	results.add(check, failedLevels...)
+	return ret
+}
+
+// checkConformities checks the object against each conformity, and adds the failures to the check.
+//
+// Calls onFail for each failed conformity, and returns the levels of the failed conformities.
+func checkConformities(
+	o *obj.EngineObj,
+	conformities []srule.LeveledMatcher,
+	check *Check,
+	onFail func(c *srule.LeveledMatcher, errs []matcher.MatcherMismatch),
+) []string {
+	failedLevels := make([]string, 0)
+	for _, c := range conformities {
+		if matches, errs := matcher.IsMatch(o, c.Matchers); !matches {
+			onFail(&c, errs)
+			failedLevels = append(failedLevels, c.Level)
+			for _, e := range errs {
+				check.Failures = append(check.Failures, e.String())
+			}
+			if len(errs) <= 0 {
+				check.Failures = append(check.Failures, "Mismatch for "+o.String())
 			}
 		}
 	}
+	return failedLevels
 }
@@ -2,122 +2,217 @@
 package runner
 
 import (
+	"context"
 	"sync"
 
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
 	"github.com/groboclown/qazaar-testing/rule-engine/engine/sog"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
+// engineRunnerState evaluates the groups one stratum per step.
+//
+// The strata come from the static group dependency graph, so each group is
+// evaluated exactly once, after every group that can feed it SOG objects.
 type engineRunnerState struct {
 	engine   *engineRunner
-	sogs     []*sog.SogBuilder
+	graph    *srule.GroupGraph
+	strata   [][]*sog.SogBuilder
+	stratum  int
+	produced map[*srule.Group][]*obj.EngineObj // SOG objects created by each group.
+	objects  []*obj.EngineObj                  // All the document and SOG objects.
 	problems problem.Adder
-	newObj   []*obj.EngineObj
-	prevObj  []*obj.EngineObj
+	results  *Results
+	workers  *pool.Pool         // Shared by the rule, coverage, and convergence checks.
+	cancel   context.CancelFunc // Drops the outstanding work in the pool.
 	stopped  bool
 }
 
 func (s *engineRunnerState) Stop() {
 	if !s.stopped {
 		s.stopped = true
+		s.cancel()
 		s.problems.Complete()
 	}
 }
 
+func (s *engineRunnerState) Results() *Results {
+	return s.results
+}
+
+func (s *engineRunnerState) Objects() []*obj.EngineObj {
+	ret := make([]*obj.EngineObj, len(s.objects))
+	copy(ret, s.objects)
+	return ret
+}
+
+func (s *engineRunnerState) Candidates(group *srule.Group) []*obj.EngineObj {
+	ret := make([]*obj.EngineObj, len(s.engine.base))
+	copy(ret, s.engine.base)
+	for _, d := range s.graph.Deps[group] {
+		ret = append(ret, s.produced[d]...)
+	}
+	return ret
+}
+
+// halt stops the engine with an error if the halting policy was reached.
+func (s *engineRunnerState) halt() bool {
+	reason := s.results.Halted()
+	if reason == "" {
+		return false
+	}
+	s.problems.AddError(nil, "Engine halted: %s", reason)
+	s.Stop()
+	return true
+}
+
 func (s *engineRunnerState) Step() bool {
-	// The whole thing is a SOG build & gather tool.
-	newObjCh := make(chan *obj.EngineObj)
-	joinedCh := joinObjAsync(newObjCh)
+	if s.stopped {
+		return false
+	}
+	if s.halt() {
+		// The remaining strata and the coverage checks never run.
+		return false
+	}
+	if s.workers.Err() != nil {
+		// The problem consumer already closed with the context, and the
+		// pool dropped the outstanding work.
+		s.stopped = true
+		return false
+	}
+	if s.stratum >= len(s.strata) {
+		s.warnIgnored()
+		// All the objects now exist, so the coverage counterparts are known.
+		addCoverageProblems(s.problems, s.engine.levelMap, checkAllCoverage(s.workers, s.objects, s.engine.rules, s.results))
+		if !s.halt() {
+			s.Stop()
+		}
+		return false
+	}
 
+	builders := s.strata[s.stratum]
+	created := make([][]*obj.EngineObj, len(builders))
 	var wg sync.WaitGroup
-
-	for _, builder := range s.sogs {
-		wg.Add(1)
-		go func(builder *sog.SogBuilder) {
+	for i, builder := range builders {
+		s.workers.Go(&wg, func() {
 			defer func() {
-				wg.Done()
 				s.problems.Recover("engineRunner.Step", recover())
 			}()
+			created[i] = s.buildGroup(builder, &wg)
+		})
+	}
+	wg.Wait()
 
-			// First step - match the objects against the SOGs.
-			// FIXME MAJOR BUG INFINITE LOOPING.
-			// The Add should indeed pass over every object, but it should
-			// only allow the builder to add the object if the builder added
-			// based on a new object.  If an old object creates a new sog, then
-			// it should not be allowed.
-			builder.Reset()
-			stale := true
-			for _, o := range s.newObj {
-				if s := builder.Add(o); s == sog.Created {
-					stale = false
-				}
-			}
-
-			if stale {
-				// This rule doesn't have any groups associated with new objects.
-				// That means running over the old objects will just re-create already
-				// discovered objects, and will put this into an infinite loop.
-				return
-			}
-
-			// For the previous objects, only add them to groups created from
-			// new objects.
-			for _, o := range s.prevObj {
-				builder.AddToExisting(o)
-			}
+	newObj := make([]*obj.EngineObj, 0)
+	for i, builder := range builders {
+		s.produced[builder.Group()] = created[i]
+		newObj = append(newObj, created[i]...)
+	}
+	s.objects = append(s.objects, newObj...)
+	s.stratum++
+
+	// Match the new SOG values against the rules.  The base objects were
+	// checked when the engine started, so this only checks the SOGs
+	// created in this step.
+	addRuleProblems(s.problems, s.engine.levelMap, checkAllAgainstRules(s.workers, newObj, s.engine.rules, s.results))
+	return true
+}
 
-			// Once the SOGs are gathered...
-			for _, si := range builder.Seal() {
-				// Match members against the Convergence.
-				for _, c := range si.Group().Convergences {
-					wg.Add(1)
-					go func(m []*obj.EngineObj, c *srule.Convergence) {
-						defer func() {
-							wg.Done()
-							s.problems.Recover("engineRunner.Step.Convergence", recover())
-						}()
-						if v := MatchConvergence(si.Group().Id, m, c, s.engine.ont); v != nil {
-							s.problems.Add(convAsProblem(s.engine.levelMap, v))
-						}
-					}(si.Members(), &c)
+// warnIgnored warns about the SOG objects that match a group which could not see them.
+//
+// The groups that may match each other's SOG objects through their members'
+// values have no evaluation order, so neither sees the other's SOG objects.
+// Only the objects themselves tell whether this lost a match.
+func (s *engineRunnerState) warnIgnored() {
+	for _, g := range s.graph.Groups {
+		for _, d := range s.graph.Ignored[g] {
+			for _, o := range s.produced[d] {
+				if descendsFrom(o, s.produced[g]) {
+					continue
+				}
+				if ok, _ := matcher.IsMatch(o, g.Matchers); ok {
+					src := make([]sources.Source, 0)
+					src = append(src, o.Source.AllSources()...)
+					src = append(src, g.Sources...)
+					s.problems.AddWarning(
+						src,
+						"group %s: matchers match %s from group %s, but the groups may match each other's SOG objects, so group %s never sees it",
+						g.Id,
+						o.String(),
+						d.Id,
+						g.Id,
+					)
+					break
 				}
-
-				// Match the new SOG values against the rules.
-				o := si.Obj()
-
-				// Add the SOG values into the objects.
-				newObjCh <- o
 			}
-		}(builder)
+		}
 	}
+}
 
-	// Wait for the async build to complete, then wrap up the async.
-	wg.Wait()
-	close(newObjCh)
-	s.prevObj = append(s.prevObj, s.newObj...)
-	s.newObj = <-joinedCh
-
-	// Return 'false' if no more SOG objects were created.
-	added := len(s.newObj) > 0
-	if !added {
-		s.Stop()
+// descendsFrom checks whether any of the objects was joined into the SOG object.
+func descendsFrom(o *obj.EngineObj, objs []*obj.EngineObj) bool {
+	ancestors := o.Ancestors()
+	for _, a := range objs {
+		if ancestors.Has(a) {
+			return true
+		}
 	}
-	return added
+	return false
 }
 
-func joinObjAsync(c <-chan *obj.EngineObj) <-chan []*obj.EngineObj {
-	done := make(chan []*obj.EngineObj)
-	objs := make([]*obj.EngineObj, 0)
-	go func() {
-		defer func() {
-			done <- objs
-			close(done)
-		}()
-		for o := range c {
-			objs = append(objs, o)
+// buildGroup creates the group's SOG instances, checks their convergences and conformities, and returns the SOG objects.
+//
+// The group only sees the document objects and the SOG objects from the groups
+// it depends on; in particular, it never sees its own SOG objects.
+func (s *engineRunnerState) buildGroup(builder *sog.SogBuilder, wg *sync.WaitGroup) []*obj.EngineObj {
+	group := builder.Group()
+	builder.Reset()
+	for _, o := range s.Candidates(group) {
+		builder.Add(o)
+	}
+
+	instances := builder.Seal()
+	ret := make([]*obj.EngineObj, len(instances))
+	for i, si := range instances {
+		// Match members against the Convergence.
+		for _, c := range group.Convergences {
+			m, id := si.Members(), si.Obj().Id
+			s.workers.Go(wg, func() {
+				defer func() {
+					s.problems.Recover("engineRunner.Step.Convergence", recover())
+				}()
+				if s.results.Halted() != "" {
+					return
+				}
+				check := Check{
+					GroupId:  group.Id,
+					File:     group.File,
+					ObjectId: id,
+					Kind:     ConvergenceCheck,
+					Key:      c.Key,
+				}
+				if v := MatchConvergence(group.Id, id, m, &c, s.engine.ont); v != nil {
+					for _, p := range convAsProblems(s.engine.levelMap, v) {
+						s.problems.Add(p)
+						check.Failures = append(check.Failures, p.Message)
+					}
+					s.results.add(check, c.Level)
+				} else {
+					s.results.add(check)
+				}
+			})
+		}
+		ret[i] = si.Obj()
+		if s.results.Halted() != "" {
+			continue
 		}
-	}()
-	return done
+		for _, p := range checkGroupConformities(si.Obj(), group, s.results) {
+			s.problems.Add(groupAsProblem(s.engine.levelMap, p))
+		}
+	}
+	return ret
 }
@@ -2,7 +2,10 @@
 package sog
 
 import (
-	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -22,6 +25,7 @@ type SogBuilder struct {
 	id      string
 	rule    *srule.Group
 	byId    map[string]*sogInstanceBuilder
+	buckets map[uint64][]*sogInstanceBuilder // Instances by the hash of their shared values.
 	factory obj.ObjFactory
 }
 
@@ -34,31 +38,42 @@ func NewBuilder(rule *srule.Group, factory obj.ObjFactory) *SogBuilder {
 		id:      rule.Id,
 		rule:    rule,
 		byId:    make(map[string]*sogInstanceBuilder),
+		buckets: make(map[uint64][]*sogInstanceBuilder),
 		factory: factory,
 	}
 }
 
 // Seal closes off all current builder synthetic instances from additional members, and returns them.
 //
-// This returns the engine object representation of the SOG instances.
+// This returns the engine object representation of the SOG instances, ordered by their identifier.
 // This can be safely called multiple times.
 func (s *SogBuilder) Seal() []SogInstance {
-	ret := make([]SogInstance, len(s.byId))
-	i := 0
-	for _, v := range s.byId {
+	ids := make([]string, 0, len(s.byId))
+	for id := range s.byId {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	ret := make([]SogInstance, len(ids))
+	for i, id := range ids {
+		v := s.byId[id]
 		ret[i] = &sogInstance{
 			members: v.members,
 			obj:     v.seal(s.factory, s.id, s.rule.Alterations),
 			group:   s.rule,
 		}
-		i++
 	}
 	return ret
 }
 
+// Group returns the group definition that this builder constructs.
+func (s *SogBuilder) Group() *srule.Group {
+	return s.rule
+}
+
 // Reset clears out the current list of known SOG instances.
 func (s *SogBuilder) Reset() {
 	s.byId = make(map[string]*sogInstanceBuilder)
+	s.buckets = make(map[uint64][]*sogInstanceBuilder)
 }
 
 type SogBuilderAddResult int
@@ -90,7 +105,8 @@ func (s *SogBuilder) add(o *obj.EngineObj, allowCreate bool) SogBuilderAddResult
 		return NoMatch
 	}
 	shared := groupSharedValues(s.rule, o)
-	si := s.matching(shared)
+	hash := sharedHash(s.rule.KeySharedValues, shared)
+	si := s.matching(hash, shared)
 	ret := Added
 	if !allowCreate && si == nil {
 		// Do not create a new instance.
@@ -100,7 +116,7 @@ func (s *SogBuilder) add(o *obj.EngineObj, allowCreate bool) SogBuilderAddResult
 		ret = Created
 
 		// Build a unique identifier
-		baseId := matchGroupId(shared)
+		baseId := matchGroupId(s.id, shared)
 		id := baseId
 		var idx int64 = 0
 		for {
@@ -114,8 +130,9 @@ func (s *SogBuilder) add(o *obj.EngineObj, allowCreate bool) SogBuilderAddResult
 		// Add the new instance to the internal record.
 		si = newSogInstance(id, shared)
 		s.byId[si.id] = si
+		s.buckets[hash] = append(s.buckets[hash], si)
 	} else {
-		if si.IsRecursion(o, context.Background()) {
+		if si.IsRecursion(o) {
 			// Do not add the item.
 			return Recursion
 		}
@@ -124,16 +141,12 @@ func (s *SogBuilder) add(o *obj.EngineObj, allowCreate bool) SogBuilderAddResult
 	return ret
 }
 
-// matchGroup finds the sog instance associated with the engine object.
+// matching finds the sog instance with the shared values, or nil if there is none.
 //
-// If the engine object does not match this rule, then it returns nil.
-// If this object creates a new instance, then it returns that instance.
-func (s *SogBuilder) matching(shared map[string]obj.DescriptorValues) *sogInstanceBuilder {
-	// No identifier derived from the shared keys + values is guaranteed unique, so
-	// we must look through item by item.
-	// Alternatively, we could devise a quick lookup scheme by values, because we
-	// know the keys are the same.  That's a future optimization, though.
-	for _, si := range s.byId {
+// Only the instances in the hash bucket need comparing, so this doesn't slow
+// down as the group gains instances.
+func (s *SogBuilder) matching(hash uint64, shared map[string]obj.DescriptorValues) *sogInstanceBuilder {
+	for _, si := range s.buckets[hash] {
 		if si.matches(shared) {
 			return si
 		}
@@ -141,27 +154,88 @@ func (s *SogBuilder) matching(shared map[string]obj.DescriptorValues) *sogInstan
 	return nil
 }
 
+// groupSharedValues returns the object's normalized values for each of the group's shared keys.
 func groupSharedValues(rule *srule.Group, o *obj.EngineObj) map[string]obj.DescriptorValues {
 	ret := make(map[string]obj.DescriptorValues)
 	for _, k := range rule.KeySharedValues {
-		val, _ := o.Value(k)
-		ret[k] = val
+		ret[k] = o.SharedValue(k)
 	}
 	return ret
 }
 
+// sharedHash hashes the normalized shared values, in the key order.
+//
+// Equal shared values always have the same hash, as the values are sorted.
+func sharedHash(keys []string, shared map[string]obj.DescriptorValues) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, k := range keys {
+		v := shared[k]
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(v.Number)))
+		h.Write(buf[:])
+		for _, n := range v.Number {
+			if n == 0 {
+				// Negative zero equals zero.
+				n = 0
+			}
+			binary.LittleEndian.PutUint64(buf[:], math.Float64bits(n))
+			h.Write(buf[:])
+		}
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(v.Text)))
+		h.Write(buf[:])
+		for _, t := range v.Text {
+			binary.LittleEndian.PutUint64(buf[:], uint64(len(t)))
+			h.Write(buf[:])
+			h.Write([]byte(t))
+		}
+	}
+	return h.Sum64()
+}
+
 // matchGroupId creates an identifier for the group instance based on the shared values.
-func matchGroupId(shared map[string]obj.DescriptorValues) string {
-	parts := make([]string, 0, len(shared)*4)
-
-	for key, value := range shared {
-		parts = append(parts, "&", key, ":")
-		joiner := ""
-		for _, n := range value.Number {
-			parts = append(parts, joiner, strconv.FormatFloat(n, 'f', -1, 64))
-			joiner = "|"
+//
+// The identifier takes the form `group(key1=a|b,key2=#1)`, with the keys and
+// each key's values sorted, so the same shared values always create the same
+// identifier.  Numbers come before text values, and start with a '#' so that
+// the number 1 and the text "1" create different identifiers.  The characters
+// that separate the parts, and the '#', are percent-escaped in the keys and
+// text values.
+func matchGroupId(groupId string, shared map[string]obj.DescriptorValues) string {
+	keys := make([]string, 0, len(shared))
+	for key := range shared {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		value := shared[key]
+		nums := make([]float64, len(value.Number))
+		copy(nums, value.Number)
+		sort.Float64s(nums)
+		texts := make([]string, len(value.Text))
+		copy(texts, value.Text)
+		sort.Strings(texts)
+
+		vals := make([]string, 0, len(nums)+len(texts))
+		for _, n := range nums {
+			vals = append(vals, "#"+strconv.FormatFloat(n, 'f', -1, 64))
 		}
+		for _, t := range texts {
+			vals = append(vals, idEscaper.Replace(t))
+		}
+		parts[i] = idEscaper.Replace(key) + "=" + strings.Join(vals, "|")
 	}
 
-	return strings.Join(parts, "")
+	return groupId + "(" + strings.Join(parts, ",") + ")"
 }
+
+var idEscaper = strings.NewReplacer(
+	"%", "%25",
+	"#", "%23",
+	"(", "%28",
+	")", "%29",
+	",", "%2C",
+	"=", "%3D",
+	"|", "%7C",
+)
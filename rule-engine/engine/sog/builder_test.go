@@ -0,0 +1,159 @@
+// Under the Apache-2.0 License
+package sog_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/sog"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+func Test_SogBuilder_Ids(t *testing.T) {
+	group := &srule.Group{
+		Id:              "g",
+		Matchers:        &srule.MatchingDescriptorSet{},
+		KeySharedValues: []string{"size", "name", "tag"},
+	}
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "number", "key": "size", "minimum": 0, "maximum": 100, "maximumCount": 10},
+			{"type": "free", "key": "name", "caseSensitive": true, "maximumCount": 10},
+			{"type": "free", "key": "tag", "caseSensitive": true, "maximumCount": 10}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	factory := obj.NewObjFactory(descriptors)
+	mk := func(size []float64, name ...string) *obj.EngineObj {
+		ob := factory.Empty(obj.ObjSource{})
+		ob.Add("size", obj.DescriptorValues{Number: size})
+		ob.Add("name", obj.DescriptorValues{Text: name})
+		return ob.Seal()
+	}
+
+	ids := func(objs ...*obj.EngineObj) []string {
+		b := sog.NewBuilder(group, factory)
+		for _, o := range objs {
+			b.Add(o)
+		}
+		ret := make([]string, 0)
+		for _, s := range b.Seal() {
+			ret = append(ret, s.Obj().Id)
+		}
+		return ret
+	}
+
+	expected := []string{
+		"g(name=a|b,size=#2|#10,tag=)",
+		"g(name=x%2Cy%3Dz,size=,tag=)",
+	}
+	first := ids(mk([]float64{10, 2}, "b", "a"), mk(nil, "x,y=z"), mk([]float64{2, 10}, "a", "b"))
+	if diff := cmp.Diff(expected, first); diff != "" {
+		t.Errorf("ids mismatch (-want +got):\n%s", diff)
+	}
+	second := ids(mk(nil, "x,y=z"), mk([]float64{2, 10}, "a", "b"))
+	if diff := cmp.Diff(expected, second); diff != "" {
+		t.Errorf("ids changed with the object order (-want +got):\n%s", diff)
+	}
+}
+
+func Test_MatchGroupId_ValueTypes(t *testing.T) {
+	// Each key has one type in the ontology, but the id must not depend on that.
+	found := []string{
+		sog.MatchGroupId("g", map[string]obj.DescriptorValues{"tag": {Number: []float64{1}}}),
+		sog.MatchGroupId("g", map[string]obj.DescriptorValues{"tag": {Text: []string{"1"}}}),
+		sog.MatchGroupId("g", map[string]obj.DescriptorValues{"tag": {Text: []string{"#1"}}}),
+		sog.MatchGroupId("g", map[string]obj.DescriptorValues{"tag": {Number: []float64{1}, Text: []string{"1"}}}),
+	}
+	expected := []string{"g(tag=#1)", "g(tag=1)", "g(tag=%231)", "g(tag=#1|1)"}
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Errorf("ids mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// bucketFactory creates objects with a case-insensitive, distinct name and a numeric size.
+func bucketFactory(t testing.TB) (obj.ObjFactory, func(size float64, name ...string) *obj.EngineObj) {
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "number", "key": "size", "minimum": -1000000, "maximum": 1000000, "maximumCount": 1},
+			{"type": "free", "key": "name", "distinct": true, "maximumCount": 10}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	factory := obj.NewObjFactory(descriptors)
+	return factory, func(size float64, name ...string) *obj.EngineObj {
+		ob := factory.Empty(obj.ObjSource{})
+		ob.Add("size", obj.DescriptorValues{Number: []float64{size}})
+		ob.Add("name", obj.DescriptorValues{Text: name})
+		return ob.Seal()
+	}
+}
+
+func Test_SogBuilder_Buckets(t *testing.T) {
+	group := &srule.Group{
+		Id:              "g",
+		Matchers:        &srule.MatchingDescriptorSet{},
+		KeySharedValues: []string{"name", "size"},
+	}
+	factory, mk := bucketFactory(t)
+	b := sog.NewBuilder(group, factory)
+	adds := []sog.SogBuilderAddResult{
+		b.Add(mk(1, "b", "A")),
+		b.Add(mk(1, "a", "B", "a")),
+		b.Add(mk(2, "a", "b")),
+		b.Add(mk(1, "a")),
+		b.AddToExisting(mk(3, "a")),
+	}
+	expected := []sog.SogBuilderAddResult{sog.Created, sog.Added, sog.Created, sog.Created, sog.NoMatch}
+	if diff := cmp.Diff(expected, adds); diff != "" {
+		t.Errorf("add results mismatch (-want +got):\n%s", diff)
+	}
+	members := make(map[string]int)
+	for _, s := range b.Seal() {
+		members[s.Obj().Id] = len(s.Members())
+	}
+	expectedMembers := map[string]int{
+		"g(name=a,size=#1)":   1,
+		"g(name=a|b,size=#1)": 2,
+		"g(name=a|b,size=#2)": 1,
+	}
+	if diff := cmp.Diff(expectedMembers, members); diff != "" {
+		t.Errorf("instance members mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// Benchmark_SogBuilder_Add groups 100k members into 10k instances, like a group with one SOG per table field.
+func Benchmark_SogBuilder_Add(b *testing.B) {
+	group := &srule.Group{
+		Id:              "g",
+		Matchers:        &srule.MatchingDescriptorSet{},
+		KeySharedValues: []string{"name", "size"},
+	}
+	factory, mk := bucketFactory(b)
+	objs := make([]*obj.EngineObj, 100000)
+	for i := range objs {
+		objs[i] = mk(float64(i%100), fmt.Sprintf("table-%d", i%10000/100))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		builder := sog.NewBuilder(group, factory)
+		for _, o := range objs {
+			builder.Add(o)
+		}
+	}
+}
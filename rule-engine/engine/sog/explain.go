@@ -0,0 +1,95 @@
+// Under the Apache-2.0 License
+package sog
+
+import (
+	"sort"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/matcher"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+// JoinExplanation describes how an object joins the SOG instances of a group.
+type JoinExplanation struct {
+	Matchers *matcher.Explanation
+	Shared   map[string]obj.DescriptorValues // The object's values for the group's shared keys.
+	Peers    []*obj.EngineObj                // Other objects that share every value with the object.
+	Near     []NearInstance                  // Other instances, closest first.
+}
+
+// NearInstance is a SOG instance the object did not join, along with the shared keys that kept it out.
+type NearInstance struct {
+	Shared     map[string]obj.DescriptorValues
+	Members    []*obj.EngineObj
+	Mismatched []string
+}
+
+// ExplainJoin explains whether the object would join a SOG instance of the group, when grouped with the candidate objects.
+//
+// The candidates should be the objects the engine builds the group from, as
+// returned by the engine state's Candidates.
+//
+// The object only joins an instance when it matches the group matchers.
+// Objects that match but have different shared values form other instances;
+// for those, this reports the shared keys with differing values.
+func ExplainJoin(group *srule.Group, o *obj.EngineObj, candidates []*obj.EngineObj) *JoinExplanation {
+	if group == nil || o == nil {
+		return nil
+	}
+	ret := &JoinExplanation{
+		Matchers: matcher.Explain(o, group.Matchers),
+		Peers:    make([]*obj.EngineObj, 0),
+		Near:     make([]NearInstance, 0),
+	}
+	if !ret.Matchers.Matched {
+		return ret
+	}
+	ret.Shared = groupSharedValues(group, o)
+	self := newSogInstance("", ret.Shared)
+
+	others := make([]*sogInstanceBuilder, 0)
+	for _, a := range candidates {
+		if a == o {
+			continue
+		}
+		if ok, _ := matcher.IsMatch(a, group.Matchers); !ok {
+			continue
+		}
+		shared := groupSharedValues(group, a)
+		if self.matches(shared) {
+			ret.Peers = append(ret.Peers, a)
+			continue
+		}
+		var si *sogInstanceBuilder
+		for _, x := range others {
+			if x.matches(shared) {
+				si = x
+				break
+			}
+		}
+		if si == nil {
+			si = newSogInstance("", shared)
+			others = append(others, si)
+		}
+		si.members = append(si.members, a)
+	}
+
+	for _, si := range others {
+		mismatched := make([]string, 0)
+		for _, k := range group.KeySharedValues {
+			a, b := ret.Shared[k], si.shared[k]
+			if !matchesDescriptorValues(&a, &b) {
+				mismatched = append(mismatched, k)
+			}
+		}
+		ret.Near = append(ret.Near, NearInstance{
+			Shared:     si.shared,
+			Members:    si.members,
+			Mismatched: mismatched,
+		})
+	}
+	sort.SliceStable(ret.Near, func(i, j int) bool {
+		return len(ret.Near[i].Mismatched) < len(ret.Near[j].Mismatched)
+	})
+	return ret
+}
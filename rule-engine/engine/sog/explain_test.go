@@ -0,0 +1,67 @@
+// Under the Apache-2.0 License
+package sog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/obj"
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/sog"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+func Test_ExplainJoin(t *testing.T) {
+	group := &srule.Group{
+		Id:              "g",
+		Matchers:        &srule.MatchingDescriptorSet{},
+		KeySharedValues: []string{"a", "b"},
+	}
+	ont, err := ingest.ParseOntology(strings.NewReader(`{
+		"$schema": "",
+		"descriptors": [
+			{"type": "number", "key": "a", "minimum": 0, "maximum": 10, "maximumCount": 1},
+			{"type": "number", "key": "b", "minimum": 0, "maximum": 10, "maximumCount": 1}
+		]
+	}`), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptors := sont.New()
+	descriptors.Add(ont)
+	factory := obj.NewObjFactory(descriptors)
+	mk := func(id string, a, b float64) *obj.EngineObj {
+		ob := factory.Empty(obj.ObjSource{})
+		ob.Add("a", obj.DescriptorValues{Number: []float64{a}})
+		ob.Add("b", obj.DescriptorValues{Number: []float64{b}})
+		o := ob.Seal()
+		o.Id = id
+		return o
+	}
+	target := mk("t", 1, 1)
+	peer := mk("p", 1, 1)
+	near := mk("n", 1, 2)
+	far := mk("f", 2, 2)
+
+	res := sog.ExplainJoin(group, target, []*obj.EngineObj{far, target, near, peer})
+	if !res.Matchers.Matched {
+		t.Fatal("expected the empty matcher to match")
+	}
+	if len(res.Peers) != 1 || res.Peers[0] != peer {
+		t.Errorf("expected peer p, found %v", res.Peers)
+	}
+	if len(res.Near) != 2 {
+		t.Fatalf("expected 2 other instances, found %v", res.Near)
+	}
+	if res.Near[0].Members[0] != near || res.Near[1].Members[0] != far {
+		t.Errorf("expected the closest instance first, found %v", res.Near)
+	}
+	if diff := cmp.Diff([]string{"b"}, res.Near[0].Mismatched); diff != "" {
+		t.Errorf("mismatched keys (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, res.Near[1].Mismatched); diff != "" {
+		t.Errorf("mismatched keys (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,6 @@
+// Exposes the unexported SOG parts to the sog_test package.
+//
+// Under the Apache-2.0 License
+package sog
+
+var MatchGroupId = matchGroupId
@@ -8,11 +8,12 @@ import (
 )
 
 type sogInstanceBuilder struct {
-	id       string
-	shared   map[string]obj.DescriptorValues
-	members  []*obj.EngineObj
-	instance *obj.EngineObj
-	sealed   bool
+	id        string
+	shared    map[string]obj.DescriptorValues
+	members   []*obj.EngineObj
+	ancestors obj.AncestorSet // Every member's ancestors.
+	instance  *obj.EngineObj
+	sealed    bool
 }
 
 func newSogInstance(
@@ -20,11 +21,12 @@ func newSogInstance(
 	shared map[string]obj.DescriptorValues,
 ) *sogInstanceBuilder {
 	return &sogInstanceBuilder{
-		id:       id,
-		shared:   shared,
-		members:  make([]*obj.EngineObj, 0),
-		instance: nil,
-		sealed:   false,
+		id:        id,
+		shared:    shared,
+		members:   make([]*obj.EngineObj, 0),
+		ancestors: make(obj.AncestorSet),
+		instance:  nil,
+		sealed:    false,
 	}
 }
 
@@ -40,6 +42,7 @@ func (s *sogInstanceBuilder) AddMember(o *obj.EngineObj) {
 	}
 	s.instance = nil
 	s.members = append(s.members, o)
+	s.ancestors.AddAll(o.Ancestors())
 }
 
 // seal closes off the SOG instance, creating the synthetic object.
@@ -58,7 +61,7 @@ func (s *sogInstanceBuilder) seal(
 
 	if s.instance == nil {
 		s.instance = asFinalizedObj(
-			factory.FromGroup(s.members, groupId),
+			factory.FromGroup(s.members, groupId, s.id),
 			s.shared,
 			alterations,
 		)
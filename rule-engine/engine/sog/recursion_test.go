@@ -25,28 +25,20 @@ func Test_IsRecursion(t *testing.T) {
 		Comments:        []string{},
 		Sources:         []sources.Source{},
 	}
-	s0 := mkSrc()
-	s1 := mkSrc()
-	s0a := mkSrc(&s0)
-	s01ab := mkSrc(&s0a, &s1)
-	obj0 := obj.EngineObj{
-		Source: s0,
-	}
-	obj0a := obj.EngineObj{
-		Source: s0a,
-	}
-	obj01ab := obj.EngineObj{
-		Source: s01ab,
-	}
+	factory := obj.NewObjFactory(nil)
+	obj0 := mkObj(factory)
+	obj1 := mkObj(factory)
+	obj0a := factory.FromGroup([]*obj.EngineObj{obj0}, "a", "a()")
+	obj01ab := factory.FromGroup([]*obj.EngineObj{obj0a, obj1}, "ab", "ab()")
 
 	// Create a new item from a group
-	builder := sog.NewBuilder(&group, obj.NewObjFactory(nil))
+	builder := sog.NewBuilder(&group, factory)
 	// ... Create the group
-	if res := builder.Add(&obj0); res != sog.Created {
+	if res := builder.Add(obj0); res != sog.Created {
 		t.Errorf("Expected created result, found %d", res)
 	}
 	// ... Add another item to the group
-	if res := builder.Add(&obj0a); res != sog.Added {
+	if res := builder.Add(obj0a); res != sog.Added {
 		t.Errorf("Expected created result, found %d", res)
 	}
 
@@ -56,36 +48,46 @@ func Test_IsRecursion(t *testing.T) {
 	}
 
 	t.Run("not-recursive", func(t *testing.T) {
-		builder := sog.NewBuilder(&group, obj.NewObjFactory(nil))
+		builder := sog.NewBuilder(&group, factory)
 		// Add the previous result.
 		if res := builder.Add(builtObj[0].Obj()); res != sog.Created {
 			t.Errorf("Expected non-recursive result, found %d", res)
 		}
 		// Add a non-recursive item to the group, but with shared history.
-		if res := builder.Add(&obj01ab); res != sog.Added {
+		if res := builder.Add(obj01ab); res != sog.Added {
 			t.Errorf("Expected non-recursive result, found %d", res)
 		}
-
-		// Ensure the result contains all added items...
 	})
 	t.Run("yes-recursive", func(t *testing.T) {
-		builder := sog.NewBuilder(&group, obj.NewObjFactory(nil))
+		builder := sog.NewBuilder(&group, factory)
 		// Add the previous result.
 		if res := builder.Add(builtObj[0].Obj()); res != sog.Created {
 			t.Errorf("Expected non-recursive result, found %d", res)
 		}
 		// Add a recursive item to the group.
-		if res := builder.Add(&obj0); res != sog.Recursion {
+		if res := builder.Add(obj0); res != sog.Recursion {
 			t.Errorf("Expected recursive result, found %d", res)
 		}
-
-		// Ensure the result does not contain the recursive item...
+		// A copy of an ancestor is still the same object.
+		if res := builder.Add(obj0.Alter().Seal()); res != sog.Recursion {
+			t.Errorf("Expected recursive result for an altered copy, found %d", res)
+		}
+	})
+	t.Run("altered-descendant", func(t *testing.T) {
+		builder := sog.NewBuilder(&group, factory)
+		// The altered copy of a SOG keeps its ancestors.
+		if res := builder.Add(obj01ab.Alter().Seal()); res != sog.Created {
+			t.Errorf("Expected created result, found %d", res)
+		}
+		if res := builder.Add(obj1); res != sog.Recursion {
+			t.Errorf("Expected recursive result, found %d", res)
+		}
+		if res := builder.Add(obj0); res != sog.Recursion {
+			t.Errorf("Expected recursive result through the nested SOG, found %d", res)
+		}
 	})
 }
 
-func mkSrc(parents ...*obj.ObjSource) obj.ObjSource {
-	return obj.ObjSource{
-		Source:  []sources.Source{},
-		Parents: parents,
-	}
+func mkObj(factory obj.ObjFactory) *obj.EngineObj {
+	return factory.Empty(obj.ObjSource{Source: []sources.Source{}}).Seal()
 }
@@ -16,11 +16,12 @@ import (
 func ReadDocuments(d *sdoc.Documents, files []string) error {
 	errs := make([]error, 0)
 	for _, f := range files {
-		src, err := ReadDocumentsFile(f)
+		in, err := readInputFile(f, ParseDocuments)
 		if err != nil {
 			errs = append(errs, err)
+			continue
 		}
-		d.Add(src)
+		d.AddFile(in.data, in.input)
 	}
 	return errors.Join(errs...)
 }
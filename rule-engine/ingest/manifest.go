@@ -0,0 +1,34 @@
+// Under the Apache-2.0 License
+package ingest
+
+import (
+	"errors"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+)
+
+// Manifest lists the input files read for a run.
+type Manifest struct {
+	Ontology       []string `json:"ontology"`
+	Rules          []string `json:"rules"`
+	Variables      []string `json:"variables"`
+	Transforms     []string `json:"transforms"`
+	TestExecutions []string `json:"testExecutions"`
+	Documents      []string `json:"documents"`
+}
+
+// FindManifest finds all the input files that ReadAll reads for the project configuration and document files.
+func FindManifest(c *config.ProjectConfig, docFiles []string) (*Manifest, error) {
+	ret := &Manifest{Documents: make([]string, len(docFiles))}
+	copy(ret.Documents, docFiles)
+	if c == nil {
+		return ret, nil
+	}
+	var errO, errR, errV, errX, errT error
+	ret.Ontology, errO = FindFiles(c.RefDirs, c.OntologyFiles)
+	ret.Rules, errR = FindFiles(c.RefDirs, c.RuleFiles)
+	ret.Variables, errV = FindFiles(c.RefDirs, c.VariableFiles)
+	ret.Transforms, errX = FindFiles(c.RefDirs, c.TransformFiles)
+	ret.TestExecutions, errT = FindFiles(c.RefDirs, c.TestExecFiles)
+	return ret, errors.Join(errO, errR, errV, errX, errT)
+}
@@ -16,11 +16,12 @@ import (
 func ReadOntology(d *sont.AllowedDescriptors, files []string) error {
 	errs := make([]error, 0)
 	for _, f := range files {
-		src, err := ReadOntologyFile(f)
+		in, err := readInputFile(f, ParseOntology)
 		if err != nil {
 			errs = append(errs, err)
+			continue
 		}
-		d.Add(src)
+		d.AddFile(in.data, in.input)
 	}
 	return errors.Join(errs...)
 }
@@ -2,25 +2,38 @@
 package ingest
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"os"
 
 	"github.com/groboclown/qazaar-testing/rule-engine/config"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/stexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sxform"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 	"github.com/groboclown/qazaar-testing/rule-engine/schema/document"
 	"github.com/groboclown/qazaar-testing/rule-engine/schema/ontology"
 	"github.com/groboclown/qazaar-testing/rule-engine/schema/rules"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/testexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/transform"
 )
 
 type AllData struct {
 	OntDescriptors *sont.AllowedDescriptors
 	RuleSets       *srule.RuleSet
 	Documents      *sdoc.Documents
+	Transforms     *sxform.Transforms
+	TestExecutions *stexec.Executions
 }
 
 // ReadAll reads and parses all the data asynchronously.
+//
+// Once read, the ontology transforms rewrite the document objects, so the
+// returned documents are in the project's ontology.
 func ReadAll(
 	c *config.ProjectConfig,
 	docFiles []string,
@@ -32,43 +45,92 @@ func ReadAll(
 		OntDescriptors: sont.New(),
 		RuleSets:       srule.New(),
 		Documents:      sdoc.New(),
+		Transforms:     sxform.New(),
+		TestExecutions: stexec.New(),
+	}
+
+	// Variables must be known before adding any rule.
+	vars, err := LoadVariables(c)
+	if err != nil {
+		probs.Error("variables", err)
+	}
+	ret.RuleSets.UseVariables(vars)
+
+	// Formats must be known before validating any value.
+	if c != nil {
+		for name, pattern := range c.Formats {
+			if err := ret.OntDescriptors.Formats().Define(name, pattern); err != nil {
+				probs.Error("format '"+name+"'", err)
+			}
+		}
 	}
 
 	ont := readOnt(c, probs, ctx)
 	rule := readRule(c, probs, ctx)
 	doc := readDocument(docFiles, probs, ctx)
+	xform := readTransform(c, probs, ctx)
+	texec := readTestExec(c, probs, ctx)
 
 	ontDone := false
 	ruleDone := false
 	docDone := false
+	xformDone := false
+	texecDone := false
 	for {
 		select {
 		case o, ok := <-ont:
 			if !ok {
 				ontDone = true
+			} else {
+				reportMissingRefs(c, o.input, ret.OntDescriptors.AddFile(o.data, o.input), probs)
 			}
-			ret.OntDescriptors.Add(o)
 		case r, ok := <-rule:
 			if !ok {
 				ruleDone = true
+			} else {
+				reportMissingRefs(c, r.input, ret.RuleSets.AddFile(r.data, r.input), probs)
 			}
-			ret.RuleSets.Add(r)
 		case d, ok := <-doc:
 			if !ok {
 				docDone = true
+			} else {
+				reportMissingRefs(c, d.input, ret.Documents.AddFile(d.data, d.input), probs)
+			}
+		case x, ok := <-xform:
+			if !ok {
+				xformDone = true
+			} else {
+				reportMissingRefs(c, x.input, ret.Transforms.AddFile(x.data, x.input), probs)
+			}
+		case e, ok := <-texec:
+			if !ok {
+				texecDone = true
+			} else {
+				reportMissingRefs(c, e.input, ret.TestExecutions.AddFile(e.data, e.input), probs)
 			}
-			ret.Documents.Add(d)
 		case <-ctx.Done():
 			ontDone = true
 			ruleDone = true
 			docDone = true
+			xformDone = true
+			texecDone = true
 		}
 
-		if ontDone && ruleDone && docDone {
+		if ontDone && ruleDone && docDone && xformDone && texecDone {
 			break
 		}
 	}
 
+	ret.Documents.Objects = ret.Transforms.Apply(ret.Documents.Objects)
+	if len(ret.TestExecutions.Executions) > 0 {
+		// The test execution objects use synthetic descriptors.
+		ret.OntDescriptors.Add(stexec.Ontology())
+	}
+	if len(ret.RuleSets.Groups) > 0 {
+		// The SOG objects use the member meta-descriptors.
+		ret.OntDescriptors.Add(sont.MemberOntology())
+	}
+
 	return &ret
 }
 
@@ -76,8 +138,8 @@ func readOnt(
 	c *config.ProjectConfig,
 	probs problem.Adder,
 	ctx context.Context,
-) <-chan *ontology.OntologyV1SchemaJson {
-	ret := make(chan *ontology.OntologyV1SchemaJson)
+) <-chan *inputFile[ontology.OntologyV1SchemaJson] {
+	ret := make(chan *inputFile[ontology.OntologyV1SchemaJson])
 
 	go func() {
 		defer close(ret)
@@ -88,12 +150,11 @@ func readOnt(
 				if !ok {
 					return
 				}
-				ont, err := ReadOntologyFile(f)
+				in, err := readInputFile(f, ParseOntology)
 				if err != nil {
 					probs.Error(f, err)
-				}
-				if ont != nil {
-					ret <- ont
+				} else {
+					ret <- in
 				}
 			case <-ctx.Done():
 				return
@@ -104,12 +165,34 @@ func readOnt(
 	return ret
 }
 
+// inputFile keeps the file with its parsed contents, so the problems and rules know where they came from.
+type inputFile[T any] struct {
+	input *sources.InputFile
+	data  *T
+}
+
+// readInputFile reads and parses the file, keeping the raw contents to locate the parsed elements.
+func readInputFile[T any](
+	f string,
+	parse func(io.Reader, string) (*T, error),
+) (*inputFile[T], error) {
+	raw, err := os.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	data, err := parse(bytes.NewReader(raw), f)
+	if err != nil {
+		return nil, err
+	}
+	return &inputFile[T]{input: sources.NewInputFile(f, raw), data: data}, nil
+}
+
 func readRule(
 	c *config.ProjectConfig,
 	probs problem.Adder,
 	ctx context.Context,
-) <-chan *rules.RulesV1SchemaJson {
-	ret := make(chan *rules.RulesV1SchemaJson)
+) <-chan *inputFile[rules.RulesV1SchemaJson] {
+	ret := make(chan *inputFile[rules.RulesV1SchemaJson])
 
 	go func() {
 		defer close(ret)
@@ -120,12 +203,72 @@ func readRule(
 				if !ok {
 					return
 				}
-				rule, err := ReadRuleFile(f)
+				in, err := readInputFile(f, ParseRule)
 				if err != nil {
 					probs.Error(f, err)
+				} else {
+					ret <- in
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ret
+}
+
+func readTransform(
+	c *config.ProjectConfig,
+	probs problem.Adder,
+	ctx context.Context,
+) <-chan *inputFile[transform.OntologyTransformV1SchemaJson] {
+	ret := make(chan *inputFile[transform.OntologyTransformV1SchemaJson])
+
+	go func() {
+		defer close(ret)
+		// The transforms apply in order, so read the files sequentially.
+		files, err := FindFiles(c.RefDirs, c.TransformFiles)
+		if err != nil {
+			probs.Error("transforms", err)
+		}
+		for _, f := range files {
+			if ctx.Err() != nil {
+				return
+			}
+			in, err := readInputFile(f, ParseTransform)
+			if err != nil {
+				probs.Error(f, err)
+			} else {
+				ret <- in
+			}
+		}
+	}()
+
+	return ret
+}
+
+func readTestExec(
+	c *config.ProjectConfig,
+	probs problem.Adder,
+	ctx context.Context,
+) <-chan *inputFile[testexec.TestExecutionV1SchemaJson] {
+	ret := make(chan *inputFile[testexec.TestExecutionV1SchemaJson])
+
+	go func() {
+		defer close(ret)
+		ch := FindFilesAsync(c.RefDirs, c.TestExecFiles, ctx)
+		for {
+			select {
+			case f, ok := <-ch:
+				if !ok {
+					return
 				}
-				if rule != nil {
-					ret <- rule
+				in, err := readInputFile(f, ParseTestExecution)
+				if err != nil {
+					probs.Error(f, err)
+				} else {
+					ret <- in
 				}
 			case <-ctx.Done():
 				return
@@ -140,8 +283,8 @@ func readDocument(
 	files []string,
 	probs problem.Adder,
 	ctx context.Context,
-) <-chan *document.DocumentDescriptionV1SchemaJson {
-	ret := make(chan *document.DocumentDescriptionV1SchemaJson)
+) <-chan *inputFile[document.DocumentDescriptionV1SchemaJson] {
+	ret := make(chan *inputFile[document.DocumentDescriptionV1SchemaJson])
 
 	go func() {
 		defer close(ret)
@@ -150,12 +293,11 @@ func readDocument(
 			if ctx.Err() != nil {
 				return
 			}
-			doc, err := ReadDocumentsFile(f)
+			in, err := readInputFile(f, ParseDocuments)
 			if err != nil {
 				probs.Error(f, err)
-			}
-			if doc != nil {
-				ret <- doc
+			} else {
+				ret <- in
 			}
 		}
 	}()
@@ -163,6 +305,29 @@ func readDocument(
 	return ret
 }
 
+// reportMissingRefs adds a problem for each source whose ref is not in the file's common source refs.
+//
+// The engine drops these sources, so this is an error in strict mode.
+func reportMissingRefs(
+	c *config.ProjectConfig,
+	input *sources.InputFile,
+	missing []sources.MissingRef,
+	probs problem.Adder,
+) {
+	level := problem.Warn
+	if c.StrictSourceRefs {
+		level = problem.Err
+	}
+	for _, m := range missing {
+		problem.WithOrigin(probs, input.Origin(m.Path)).AddProblem(
+			nil,
+			level,
+			"source ref '%s' is not in the commonSourceRefs",
+			m.Ref,
+		)
+	}
+}
+
 // Problems joins all problem sets of the loaded files.
 func (a *AllData) Problems() *problem.ProblemSet {
 	ret := problem.New()
@@ -171,6 +336,13 @@ func (a *AllData) Problems() *problem.ProblemSet {
 		ret.Add(a.OntDescriptors.Problems.Problems()...)
 		ret.Add(a.RuleSets.Problems.Problems()...)
 		ret.Add(a.Documents.Problems.Problems()...)
+		if a.Transforms != nil {
+			// Transforms are optional.
+			ret.Add(a.Transforms.Problems.Problems()...)
+		}
+		if a.TestExecutions != nil {
+			ret.Add(a.TestExecutions.Problems.Problems()...)
+		}
 	}
 
 	return ret
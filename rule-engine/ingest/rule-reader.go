@@ -16,11 +16,12 @@ import (
 func ReadRule(d *srule.RuleSet, files []string) error {
 	errs := make([]error, 0)
 	for _, f := range files {
-		src, err := ReadRuleFile(f)
+		in, err := readInputFile(f, ParseRule)
 		if err != nil {
 			errs = append(errs, err)
+			continue
 		}
-		d.Add(src)
+		d.AddFile(in.data, in.input)
 	}
 	return errors.Join(errs...)
 }
@@ -9,15 +9,23 @@ import (
 )
 
 // Add adds in the documents from the data-exchange format into the simplified form.
-func (d *Documents) Add(src *document.DocumentDescriptionV1SchemaJson) {
+//
+// Returns the sources whose ref is not in the document's common source refs.
+func (d *Documents) Add(src *document.DocumentDescriptionV1SchemaJson) []sources.MissingRef {
+	return d.AddFile(src, nil)
+}
+
+// AddFile adds in the documents read from the input file.
+func (d *Documents) AddFile(src *document.DocumentDescriptionV1SchemaJson, input *sources.InputFile) []sources.MissingRef {
 	if src == nil || d == nil {
-		return
+		return nil
 	}
-	prep := d.sources.PrepareDocument(&src.CommonSourceRefs)
+	prep := d.sources.PrepareDocument(&src.CommonSourceRefs, input)
 
-	for _, obj := range src.Objects {
-		d.Objects = append(d.Objects, d.updateSources(&obj, prep))
+	for i, obj := range src.Objects {
+		d.Objects = append(d.Objects, d.updateSources(&obj, prep.Index("objects", i)))
 	}
+	return prep.MissingRefs()
 }
 
 func (d *Documents) updateSources(
@@ -29,5 +37,6 @@ func (d *Documents) updateSources(
 		Descriptors: descriptor.JoinDocumentDescriptors(obj.Descriptors),
 		Id:          obj.Id,
 		Sources:     prep.DocumentObject(obj),
+		Origin:      prep.Origin(),
 	}
 }
@@ -14,6 +14,7 @@ type DocumentObject struct {
 	Descriptors []*descriptor.Descriptor
 	Id          document.Id
 	Sources     []sources.Source
+	Origin      sources.Origin
 }
 
 // Documents simplifies and unifies the representation of documents.
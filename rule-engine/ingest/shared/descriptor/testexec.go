@@ -0,0 +1,22 @@
+// Under the Apache-2.0 License
+package descriptor
+
+import "github.com/groboclown/qazaar-testing/rule-engine/schema/testexec"
+
+func DecodeTestExecValues(vals []testexec.DocumentDescriptorValuesElem) []DescriptorValue {
+	ret := make([]DescriptorValue, len(vals))
+	for i, v := range vals {
+		ret[i] = Decode(v)
+	}
+	return ret
+}
+
+// JoinTestExecDescriptors converts the test execution descriptors into the shared descriptor format.
+func JoinTestExecDescriptors(descs []testexec.DocumentDescriptor) []*Descriptor {
+	ret := make([]*Descriptor, len(descs))
+	for i, d := range descs {
+		t, n := Join(DecodeTestExecValues(d.Values))
+		ret[i] = JoinKeyValues(string(d.Key), t, n)
+	}
+	return ret
+}
@@ -0,0 +1,12 @@
+// Under the Apache-2.0 License
+package descriptor
+
+import "github.com/groboclown/qazaar-testing/rule-engine/schema/transform"
+
+func DecodeTransformValues(vals []transform.TransformOutputValuesElem) []DescriptorValue {
+	ret := make([]DescriptorValue, len(vals))
+	for i, v := range vals {
+		ret[i] = Decode(v)
+	}
+	return ret
+}
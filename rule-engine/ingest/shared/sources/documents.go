@@ -4,18 +4,21 @@ package sources
 import "github.com/groboclown/qazaar-testing/rule-engine/schema/document"
 
 type DocumentSource struct {
-	MissingRefs []document.Id
+	missing *[]MissingRef
+	path    string
+	input   *InputFile
 
 	sg   *SourceGen
 	refs map[document.Id]*document.CommonDocumentSource
 }
 
 // PrepareDocument prepares a structure for extracting universal sources from document values.
-func (sg *SourceGen) PrepareDocument(cdl *document.CommonDocumentSourceList) *DocumentSource {
+func (sg *SourceGen) PrepareDocument(cdl *document.CommonDocumentSourceList, input *InputFile) *DocumentSource {
 	ret := &DocumentSource{
-		MissingRefs: make([]document.Id, 0),
-		sg:          sg,
-		refs:        make(map[document.Id]*document.CommonDocumentSource),
+		missing: &[]MissingRef{},
+		input:   input,
+		sg:      sg,
+		refs:    make(map[document.Id]*document.CommonDocumentSource),
 	}
 	if cdl != nil {
 		for _, cds := range *cdl {
@@ -39,8 +42,44 @@ func (ds *DocumentSource) DocumentObject(obj *document.DocumentObject) []Source
 				a:   s.A,
 			})
 		} else {
-			ds.MissingRefs = append(ds.MissingRefs, s.Ref)
+			*ds.missing = append(*ds.missing, MissingRef{Ref: string(s.Ref), Path: ds.path})
 		}
 	}
 	return ret
 }
+
+// Index returns the sources for the element at the index of the field, so the missing refs name the element.
+func (ds *DocumentSource) Index(field string, i int) *DocumentSource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = indexPath(ds.path, field, i)
+	return &ret
+}
+
+// MissingRefs returns the sources whose ref is not in the common source refs.
+func (ds *DocumentSource) MissingRefs() []MissingRef {
+	if ds == nil {
+		return nil
+	}
+	return *ds.missing
+}
+
+// Field returns the sources for the element in the field.
+func (ds *DocumentSource) Field(name string) *DocumentSource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = fieldPath(ds.path, name)
+	return &ret
+}
+
+// Origin returns where the ingest read the element.
+func (ds *DocumentSource) Origin() Origin {
+	if ds == nil {
+		return Origin{}
+	}
+	return ds.input.Origin(ds.path)
+}
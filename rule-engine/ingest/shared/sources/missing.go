@@ -0,0 +1,10 @@
+// Under the Apache-2.0 License
+package sources
+
+// MissingRef is a source whose ref is not in the file's common source refs.
+//
+// The source is dropped from the element, which loses its traceability.
+type MissingRef struct {
+	Ref  string
+	Path string // JSON pointer to the element with the source.
+}
@@ -6,18 +6,21 @@ import (
 )
 
 type OntologySource struct {
-	MissingRefs []ontology.Id
+	missing *[]MissingRef
+	path    string
+	input   *InputFile
 
 	sg   *SourceGen
 	refs map[ontology.Id]*ontology.CommonDocumentSource
 }
 
 // PrepareOntology prepares a structure for extracting universal sources from document values.
-func (sg *SourceGen) PrepareOntology(cdl *ontology.CommonDocumentSourceList) *OntologySource {
+func (sg *SourceGen) PrepareOntology(cdl *ontology.CommonDocumentSourceList, input *InputFile) *OntologySource {
 	ret := &OntologySource{
-		MissingRefs: make([]ontology.Id, 0),
-		sg:          sg,
-		refs:        make(map[ontology.Id]*ontology.CommonDocumentSource),
+		missing: &[]MissingRef{},
+		input:   input,
+		sg:      sg,
+		refs:    make(map[ontology.Id]*ontology.CommonDocumentSource),
 	}
 	if cdl != nil {
 		for _, cds := range *cdl {
@@ -65,8 +68,44 @@ func (ds *OntologySource) DocumentSources(obj ontology.DocumentSources) []Source
 				a:   s.A,
 			})
 		} else {
-			ds.MissingRefs = append(ds.MissingRefs, s.Ref)
+			*ds.missing = append(*ds.missing, MissingRef{Ref: string(s.Ref), Path: ds.path})
 		}
 	}
 	return ret
 }
+
+// Index returns the sources for the element at the index of the field, so the missing refs name the element.
+func (ds *OntologySource) Index(field string, i int) *OntologySource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = indexPath(ds.path, field, i)
+	return &ret
+}
+
+// MissingRefs returns the sources whose ref is not in the common source refs.
+func (ds *OntologySource) MissingRefs() []MissingRef {
+	if ds == nil {
+		return nil
+	}
+	return *ds.missing
+}
+
+// Field returns the sources for the element in the field.
+func (ds *OntologySource) Field(name string) *OntologySource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = fieldPath(ds.path, name)
+	return &ret
+}
+
+// Origin returns where the ingest read the element.
+func (ds *OntologySource) Origin() Origin {
+	if ds == nil {
+		return Origin{}
+	}
+	return ds.input.Origin(ds.path)
+}
@@ -0,0 +1,147 @@
+// Under the Apache-2.0 License
+package sources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Origin is where the ingest read an element: the input file, and the element's JSON pointer in it.
+//
+// Unlike the declared sources, which the authors list, every ingested element has an origin.
+type Origin struct {
+	File    string
+	Pointer string
+	Line    int // 1-based line of the element, or 0 if not known.
+	Column  int // 1-based column of the element, in characters, or 0 if not known.
+}
+
+// String returns the origin as "file#pointer", with the line and column when known.
+func (o Origin) String() string {
+	ret := o.File + "#" + o.Pointer
+	if o.Line > 0 {
+		ret += fmt.Sprintf(" (line %d, column %d)", o.Line, o.Column)
+	}
+	return ret
+}
+
+// IsZero checks whether the origin has no information.
+func (o Origin) IsZero() bool {
+	return o.File == "" && o.Pointer == ""
+}
+
+// InputFile locates the elements of a read JSON file.
+type InputFile struct {
+	Name      string
+	data      []byte
+	offsets   map[string]int
+	lineStart []int
+}
+
+// NewInputFile creates the input file for the file's contents.
+//
+// The contents may be nil, in which case the origins have no line or column.
+func NewInputFile(name string, data []byte) *InputFile {
+	ret := &InputFile{Name: name, data: data}
+	if len(data) > 0 {
+		ret.offsets = containerOffsets(data)
+		ret.lineStart = []int{0}
+		for i, b := range data {
+			if b == '\n' {
+				ret.lineStart = append(ret.lineStart, i+1)
+			}
+		}
+	}
+	return ret
+}
+
+// Origin returns the origin of the element at the JSON pointer.
+func (f *InputFile) Origin(pointer string) Origin {
+	if f == nil {
+		return Origin{Pointer: pointer}
+	}
+	ret := Origin{File: f.Name, Pointer: pointer}
+	if off, ok := f.offsets[pointer]; ok {
+		line := sort.Search(len(f.lineStart), func(i int) bool { return f.lineStart[i] > off }) - 1
+		ret.Line = line + 1
+		ret.Column = utf8.RuneCount(f.data[f.lineStart[line]:off]) + 1
+	}
+	return ret
+}
+
+// containerOffsets finds the byte offset of each JSON object and array in the data, by JSON pointer.
+//
+// The ingested elements are all objects, so this skips the offsets of the other values.
+func containerOffsets(data []byte) map[string]int {
+	ret := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// A parse error leaves the offsets found so far; the parser reports the error.
+	_ = walkValue(dec, data, "", ret)
+	return ret
+}
+
+func walkValue(dec *json.Decoder, data []byte, pointer string, offsets map[string]int) error {
+	start := valueStart(data, int(dec.InputOffset()))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+	offsets[pointer] = start
+	switch delim {
+	case '{':
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			if err := walkValue(dec, data, pointer+"/"+escapePointer(name), offsets); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; dec.More(); i++ {
+			if err := walkValue(dec, data, pointer+"/"+strconv.Itoa(i), offsets); err != nil {
+				return err
+			}
+		}
+	}
+	// The closing delimiter.
+	_, err = dec.Token()
+	return err
+}
+
+// valueStart skips the separators between the decoder offset and the next value.
+func valueStart(data []byte, off int) int {
+	for off < len(data) {
+		switch data[off] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			off++
+		default:
+			return off
+		}
+	}
+	return off
+}
+
+func indexPath(path string, field string, i int) string {
+	return fieldPath(path, field) + "/" + strconv.Itoa(i)
+}
+
+func fieldPath(path string, field string) string {
+	return path + "/" + escapePointer(field)
+}
+
+// escapePointer escapes the '~' and '/' in a JSON pointer field name.
+func escapePointer(field string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(field, "~", "~0"), "/", "~1")
+}
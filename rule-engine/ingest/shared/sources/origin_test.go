@@ -0,0 +1,72 @@
+// Under the Apache-2.0 License
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+)
+
+const originJson = `{
+  "rules": [
+    {"id": "r1"},
+    {
+      "id": "é/~",
+      "matchingDescriptors": [
+        {"key": "a"}
+      ]
+    }
+  ],
+  "a/b": {"c~d": []}
+}`
+
+func Test_InputFile_Origin(t *testing.T) {
+	f := sources.NewInputFile("x.json", []byte(originJson))
+	for _, tc := range []struct {
+		pointer string
+		line    int
+		column  int
+	}{
+		{"", 1, 1},
+		{"/rules", 2, 12},
+		{"/rules/0", 3, 5},
+		{"/rules/1", 4, 5},
+		{"/rules/1/matchingDescriptors/0", 7, 9},
+		{"/a~1b", 11, 10},
+		{"/a~1b/c~0d", 11, 18},
+		{"/rules/0/id", 0, 0},
+		{"/missing", 0, 0},
+	} {
+		t.Run(tc.pointer, func(t *testing.T) {
+			o := f.Origin(tc.pointer)
+			if o.File != "x.json" || o.Pointer != tc.pointer {
+				t.Errorf("bad origin location: %s", o.String())
+			}
+			if o.Line != tc.line || o.Column != tc.column {
+				t.Errorf("expected line %d, column %d; found line %d, column %d", tc.line, tc.column, o.Line, o.Column)
+			}
+		})
+	}
+}
+
+func Test_InputFile_Origin_NoData(t *testing.T) {
+	o := sources.NewInputFile("x.json", nil).Origin("/rules/0")
+	if o.String() != "x.json#/rules/0" {
+		t.Errorf("bad origin: %s", o.String())
+	}
+
+	var f *sources.InputFile
+	o = f.Origin("/rules/0")
+	if o.File != "" || o.Pointer != "/rules/0" || o.IsZero() {
+		t.Errorf("bad nil file origin: %s", o.String())
+	}
+}
+
+func Test_RulesSource_Origin(t *testing.T) {
+	s := sources.SourceGenerator()
+	prep := s.PrepareRules(nil, sources.NewInputFile("x.json", []byte(originJson)))
+	o := prep.Index("rules", 1).Field("matchingDescriptors").Field("0").Origin()
+	if o.Pointer != "/rules/1/matchingDescriptors/0" || o.Line != 7 {
+		t.Errorf("bad rule source origin: %s", o.String())
+	}
+}
@@ -6,18 +6,21 @@ import (
 )
 
 type RulesSource struct {
-	MissingRefs []rules.Id
+	missing *[]MissingRef
+	path    string
+	input   *InputFile
 
 	sg   *SourceGen
 	refs map[rules.Id]*rules.CommonDocumentSource
 }
 
 // PrepareOntology prepares a structure for extracting universal sources from document values.
-func (sg *SourceGen) PrepareRules(cdl *rules.CommonDocumentSourceList) *RulesSource {
+func (sg *SourceGen) PrepareRules(cdl *rules.CommonDocumentSourceList, input *InputFile) *RulesSource {
 	ret := &RulesSource{
-		MissingRefs: make([]rules.Id, 0),
-		sg:          sg,
-		refs:        make(map[rules.Id]*rules.CommonDocumentSource),
+		missing: &[]MissingRef{},
+		input:   input,
+		sg:      sg,
+		refs:    make(map[rules.Id]*rules.CommonDocumentSource),
 	}
 	if cdl != nil {
 		for _, cds := range *cdl {
@@ -41,8 +44,44 @@ func (ds *RulesSource) DocumentSources(obj rules.DocumentSources) []Source {
 				a:   s.A,
 			})
 		} else {
-			ds.MissingRefs = append(ds.MissingRefs, s.Ref)
+			*ds.missing = append(*ds.missing, MissingRef{Ref: string(s.Ref), Path: ds.path})
 		}
 	}
 	return ret
 }
+
+// Index returns the sources for the element at the index of the field, so the missing refs name the element.
+func (ds *RulesSource) Index(field string, i int) *RulesSource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = indexPath(ds.path, field, i)
+	return &ret
+}
+
+// MissingRefs returns the sources whose ref is not in the common source refs.
+func (ds *RulesSource) MissingRefs() []MissingRef {
+	if ds == nil {
+		return nil
+	}
+	return *ds.missing
+}
+
+// Field returns the sources for the element in the field.
+func (ds *RulesSource) Field(name string) *RulesSource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = fieldPath(ds.path, name)
+	return &ret
+}
+
+// Origin returns where the ingest read the element.
+func (ds *RulesSource) Origin() Origin {
+	if ds == nil {
+		return Origin{}
+	}
+	return ds.input.Origin(ds.path)
+}
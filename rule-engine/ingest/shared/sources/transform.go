@@ -0,0 +1,87 @@
+// Under the Apache-2.0 License
+package sources
+
+import (
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/transform"
+)
+
+type TransformSource struct {
+	missing *[]MissingRef
+	path    string
+	input   *InputFile
+
+	sg   *SourceGen
+	refs map[transform.Id]*transform.CommonDocumentSource
+}
+
+// PrepareTransform prepares a structure for extracting universal sources from ontology transform values.
+func (sg *SourceGen) PrepareTransform(cdl *transform.CommonDocumentSourceList, input *InputFile) *TransformSource {
+	ret := &TransformSource{
+		missing: &[]MissingRef{},
+		input:   input,
+		sg:      sg,
+		refs:    make(map[transform.Id]*transform.CommonDocumentSource),
+	}
+	if cdl != nil {
+		for _, cds := range *cdl {
+			ret.refs[cds.Id] = &cds
+		}
+	}
+	return ret
+}
+
+// DocumentSources converts the sources into the universal source value.
+func (ds *TransformSource) DocumentSources(obj transform.DocumentSources) []Source {
+	if obj == nil || ds == nil {
+		return nil
+	}
+	ret := make([]Source, 0)
+	for _, s := range obj {
+		if ref, ok := ds.refs[s.Ref]; ok {
+			ir := ds.sg.addRef(ref.Loc, ref.Rep, ref.Ver)
+			ret = append(ret, Source{
+				ref: ir,
+				a:   s.A,
+			})
+		} else {
+			*ds.missing = append(*ds.missing, MissingRef{Ref: string(s.Ref), Path: ds.path})
+		}
+	}
+	return ret
+}
+
+// Index returns the sources for the element at the index of the field, so the missing refs name the element.
+func (ds *TransformSource) Index(field string, i int) *TransformSource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = indexPath(ds.path, field, i)
+	return &ret
+}
+
+// MissingRefs returns the sources whose ref is not in the common source refs.
+func (ds *TransformSource) MissingRefs() []MissingRef {
+	if ds == nil {
+		return nil
+	}
+	return *ds.missing
+}
+
+// Field returns the sources for the element in the field.
+func (ds *TransformSource) Field(name string) *TransformSource {
+	if ds == nil {
+		return nil
+	}
+	ret := *ds
+	ret.path = fieldPath(ds.path, name)
+	return &ret
+}
+
+// Origin returns where the ingest read the element.
+func (ds *TransformSource) Origin() Origin {
+	if ds == nil {
+		return Origin{}
+	}
+	return ds.input.Origin(ds.path)
+}
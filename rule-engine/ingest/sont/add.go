@@ -11,14 +11,22 @@ import (
 )
 
 // Add adds all the descriptors in the ontology document into the descriptors structure.
-func (s *AllowedDescriptors) Add(obj *ontology.OntologyV1SchemaJson) {
+//
+// Returns the sources whose ref is not in the document's common source refs.
+func (s *AllowedDescriptors) Add(obj *ontology.OntologyV1SchemaJson) []sources.MissingRef {
+	return s.AddFile(obj, nil)
+}
+
+// AddFile adds all the descriptors in the ontology document read from the input file.
+func (s *AllowedDescriptors) AddFile(obj *ontology.OntologyV1SchemaJson, input *sources.InputFile) []sources.MissingRef {
 	if obj == nil || s == nil {
-		return
+		return nil
 	}
-	prep := s.sources.PrepareOntology(&obj.CommonSourceRefs)
-	for _, d := range obj.Descriptors {
-		s.addDescriptor(&d, prep)
+	prep := s.sources.PrepareOntology(&obj.CommonSourceRefs, input)
+	for i, d := range obj.Descriptors {
+		s.addDescriptor(&d, prep.Index("descriptors", i))
 	}
+	return prep.MissingRefs()
 }
 
 // addDescriptor add the right typed descriptor.
@@ -38,7 +46,7 @@ func (s *AllowedDescriptors) addDescriptor(
 		sel.SelectHandlerMap{
 			"enum": func(val map[string]any) error {
 				var desc ontology.EnumDescriptor
-				err := mapstructure.Decode(val, &desc)
+				err := mapstructure.Decode(withDefaults(val, enumDefaults), &desc)
 				if err != nil {
 					return err
 				}
@@ -47,7 +55,7 @@ func (s *AllowedDescriptors) addDescriptor(
 			},
 			"free": func(val map[string]any) error {
 				var desc ontology.FreeDescriptor
-				err := mapstructure.Decode(val, &desc)
+				err := mapstructure.Decode(withDefaults(val, freeDefaults), &desc)
 				if err != nil {
 					return err
 				}
@@ -56,7 +64,7 @@ func (s *AllowedDescriptors) addDescriptor(
 			},
 			"number": func(val map[string]any) error {
 				var desc ontology.NumericDescriptor
-				err := mapstructure.Decode(val, &desc)
+				err := mapstructure.Decode(withDefaults(val, numericDefaults), &desc)
 				if err != nil {
 					return err
 				}
@@ -66,14 +74,39 @@ func (s *AllowedDescriptors) addDescriptor(
 		},
 	)
 	if err != nil {
-		s.Problems.AddError(
+		s.Problems.AddProblemAt(
+			src.Origin(),
 			nil,
+			problem.Err,
 			"error decoding descriptor: %s",
 			err.Error(),
 		)
 	}
 }
 
+// Schema default values for the descriptors.
+//
+// The generated UnmarshalJSON sets these, but decoding from the generic map skips them,
+// which would leave an unset maximum count as 0.
+var (
+	enumDefaults    = map[string]any{"maximumCount": 1}
+	freeDefaults    = map[string]any{"maximumCount": 1, "maximumLength": 1000}
+	numericDefaults = map[string]any{"maximumCount": 1}
+)
+
+func withDefaults(val map[string]any, defaults map[string]any) map[string]any {
+	ret := make(map[string]any, len(val)+len(defaults))
+	for k, v := range defaults {
+		ret[k] = v
+	}
+	for k, v := range val {
+		if v != nil {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
 func (s *AllowedDescriptors) addEnum(obj *ontology.EnumDescriptor, src *sources.OntologySource) {
 	if obj == nil || s == nil {
 		return
@@ -86,9 +119,11 @@ func (s *AllowedDescriptors) addEnum(obj *ontology.EnumDescriptor, src *sources.
 		Comments:     comments.JoinOntComments(obj.Comment, obj.Comments),
 		Distinct:     obj.Distinct,
 		Enum:         enumMap(obj.Enum),
+		Values:       obj.Enum,
 		Key:          string(obj.Key),
 		MaximumCount: obj.MaximumCount,
 		Sources:      sl,
+		Origin:       src.Origin(),
 	}
 }
 
@@ -117,6 +152,7 @@ func (s *AllowedDescriptors) addFree(obj *ontology.FreeDescriptor, src *sources.
 		MaximumCount:  obj.MaximumCount,
 		MaximumLength: obj.MaximumLength,
 		Sources:       sl,
+		Origin:        src.Origin(),
 	}
 }
 
@@ -136,6 +172,7 @@ func (s *AllowedDescriptors) addNumeric(obj *ontology.NumericDescriptor, src *so
 		Minimum:      float64(obj.Minimum),
 		MaximumCount: obj.MaximumCount,
 		Sources:      sl,
+		Origin:       src.Origin(),
 	}
 }
 
@@ -146,16 +183,19 @@ func (s *AllowedDescriptors) hasDup(
 	p *problem.ProblemSet,
 ) bool {
 	k := string(key)
-	if _, ok := s.keyTypes[k]; ok {
+	if prev, ok := s.keyTypes[k]; ok {
+		// Report both definitions, so the user can find the one to remove.
 		p.AddWarning(
-			src,
-			"%s: duplicate key (%s)",
+			sources.Join(s.keySources[k], src...),
+			"%s: duplicate key (%s); already defined as %s",
 			keyName[t],
 			key,
+			keyName[prev],
 		)
 		return true
 	}
 	s.keyTypes[k] = t
+	s.keySources[k] = src
 	return false
 }
 
@@ -165,7 +205,7 @@ func convertConstraints(
 ) []ValueConstraint {
 	ret := make([]ValueConstraint, len(cons))
 	for i, v := range cons {
-		ret[i] = convertConstraint(&v, src)
+		ret[i] = convertConstraint(&v, src.Index("constraints", i))
 	}
 	return ret
 }
@@ -39,4 +39,62 @@ func Test_Add(t *testing.T) {
 	if len(s.Numerics()) != 1 {
 		t.Error("Descriptor[2] was not a numeric")
 	}
+
+	// Decoding must still apply the schema defaults.
+	if f := s.Free("fk1"); f == nil || f.MaximumCount != 1 || f.MaximumLength != 1000 {
+		t.Errorf("fk1 did not use the default maximums: %+v", f)
+	}
+}
+
+func Test_Add_Duplicate(t *testing.T) {
+	s := sont.New()
+	for _, text := range []string{
+		`{"$schema": "", "commonSourceRefs": [{"id": "a", "rep": "git", "loc": "a.ont.json"}],
+			"descriptors": [{"type": "enum", "key": "k", "enum": ["x"], "sources": [{"ref": "a"}]}]}`,
+		`{"$schema": "", "commonSourceRefs": [{"id": "b", "rep": "git", "loc": "b.ont.json"}],
+			"descriptors": [{"type": "free", "key": "k", "sources": [{"ref": "b"}]}]}`,
+	} {
+		var ont ontology.OntologyV1SchemaJson
+		if err := json.Unmarshal([]byte(text), &ont); err != nil {
+			t.Fatal(err)
+		}
+		s.Add(&ont)
+	}
+
+	probs := s.Problems.Problems()
+	if len(probs) != 1 {
+		t.Fatalf("expected 1 problem, found %v", probs)
+	}
+	var locs []string
+	for _, src := range probs[0].Sources {
+		locs = append(locs, src.Loc())
+	}
+	if len(locs) != 2 || locs[0] != "a.ont.json" || locs[1] != "b.ont.json" {
+		t.Errorf("expected both definition sources, found %v", locs)
+	}
+	if s.Type("k") != sont.EnumDescriptorType {
+		t.Error("the duplicate replaced the first definition")
+	}
+}
+
+func Test_Add_MissingRefs(t *testing.T) {
+	var ont ontology.OntologyV1SchemaJson
+	if err := json.Unmarshal([]byte(`{"$schema": "", "commonSourceRefs": [{"id": "a", "rep": "git", "loc": "a.ont.json"}],
+		"descriptors": [
+			{"type": "enum", "key": "k1", "enum": ["x"], "sources": [{"ref": "a"}]},
+			{"type": "free", "key": "k2", "sources": [{"ref": "a"}, {"ref": "b"}],
+				"constraints": [{"type": "pattern", "pattern": "x", "sources": [{"ref": "c"}]}]}
+		]}`), &ont); err != nil {
+		t.Fatal(err)
+	}
+	missing := sont.New().Add(&ont)
+	if len(missing) != 2 {
+		t.Fatalf("expected 2 missing refs, found %v", missing)
+	}
+	if missing[0].Ref != "b" || missing[0].Path != "/descriptors/1" {
+		t.Errorf("expected ref b at /descriptors/1, found %+v", missing[0])
+	}
+	if missing[1].Ref != "c" || missing[1].Path != "/descriptors/1/constraints/0" {
+		t.Errorf("expected ref c at /descriptors/1/constraints/0, found %+v", missing[1])
+	}
 }
@@ -0,0 +1,102 @@
+// Under the Apache-2.0 License
+package sont
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Built-in value formats for the "format" value constraint.
+const (
+	UriFormat        = "uri"
+	SemverFormat     = "semver"
+	DateFormat       = "date"
+	DateTimeFormat   = "date-time"
+	UuidFormat       = "uuid"
+	EmailFormat      = "email"
+	TicketIdFormat   = "ticket-id"
+	HttpMethodFormat = "http-method"
+)
+
+// Formats maps each named value format to the check for its values.
+type Formats struct {
+	checks map[string]func(string) bool
+}
+
+var (
+	semverPattern     = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	uuidPattern       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ticketIdPattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[1-9][0-9]*$`)
+	httpMethodPattern = regexp.MustCompile(`^(GET|HEAD|POST|PUT|DELETE|CONNECT|OPTIONS|TRACE|PATCH)$`)
+)
+
+// BuiltinFormats creates the formats with only the built-in formats.
+//
+// The ticket id format is the common "PROJECT-123" form; projects with another
+// form can define their own "ticket-id" format.
+func BuiltinFormats() *Formats {
+	return &Formats{checks: map[string]func(string) bool{
+		UriFormat: func(v string) bool {
+			u, err := url.Parse(v)
+			return err == nil && u.Scheme != ""
+		},
+		SemverFormat: semverPattern.MatchString,
+		DateFormat: func(v string) bool {
+			_, err := time.Parse(time.DateOnly, v)
+			return err == nil
+		},
+		DateTimeFormat: func(v string) bool {
+			_, err := time.Parse(time.RFC3339, v)
+			return err == nil
+		},
+		UuidFormat: uuidPattern.MatchString,
+		EmailFormat: func(v string) bool {
+			a, err := mail.ParseAddress(v)
+			return err == nil && a.Name == "" && a.Address == v
+		},
+		TicketIdFormat:   ticketIdPattern.MatchString,
+		HttpMethodFormat: httpMethodPattern.MatchString,
+	}}
+}
+
+// Define adds a named format, whose values must entirely match the regular expression.
+//
+// This replaces any existing format with the same name, including the built-in ones.
+func (f *Formats) Define(name string, pattern string) error {
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return err
+	}
+	f.checks[name] = re.MatchString
+	return nil
+}
+
+// Match checks the value against the named format.
+//
+// Returns false for 'defined' if no format has the name.
+func (f *Formats) Match(name string, value string) (matched bool, defined bool) {
+	check, ok := f.checks[name]
+	if !ok {
+		return false, false
+	}
+	return check(value), true
+}
+
+// Defined returns true if a format has the name.
+func (f *Formats) Defined(name string) bool {
+	_, ok := f.checks[name]
+	return ok
+}
+
+// Names returns the sorted format names.
+func (f *Formats) Names() []string {
+	ret := make([]string, 0, len(f.checks))
+	for k := range f.checks {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,72 @@
+// Under the Apache-2.0 License
+package sont_test
+
+import (
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+)
+
+func Test_Formats_Builtin(t *testing.T) {
+	for _, tc := range []struct {
+		format string
+		value  string
+		match  bool
+	}{
+		{"uri", "https://example.com/a?b=c", true},
+		{"uri", "example.com", false},
+		{"semver", "1.2.3-rc.1+build.5", true},
+		{"semver", "1.02.3", false},
+		{"date", "2024-02-29", true},
+		{"date", "2023-02-29", false},
+		{"date-time", "2024-02-29T10:11:12Z", true},
+		{"date-time", "2024-02-29 10:11:12", false},
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000", true},
+		{"uuid", "123e4567e89b12d3a456426614174000", false},
+		{"email", "a.b@example.com", true},
+		{"email", "A B <a.b@example.com>", false},
+		{"ticket-id", "PROJ-123", true},
+		{"ticket-id", "proj-123", false},
+		{"http-method", "PATCH", true},
+		{"http-method", "get", false},
+	} {
+		t.Run(tc.format+" "+tc.value, func(t *testing.T) {
+			matched, defined := sont.BuiltinFormats().Match(tc.format, tc.value)
+			if !defined {
+				t.Fatal("format not defined")
+			}
+			if matched != tc.match {
+				t.Errorf("expected match %v, found %v", tc.match, matched)
+			}
+		})
+	}
+}
+
+func Test_Formats_Define(t *testing.T) {
+	f := sont.BuiltinFormats()
+	if _, defined := f.Match("team", "x"); defined {
+		t.Error("'team' defined before adding it")
+	}
+	if err := f.Define("team", "[a-z]+|core"); err != nil {
+		t.Fatal(err)
+	}
+	// The pattern must match the whole value, even with alternation.
+	if m, _ := f.Match("team", "web-1"); m {
+		t.Error("matched a partial value")
+	}
+	if m, _ := f.Match("team", "web"); !m {
+		t.Error("did not match 'web'")
+	}
+
+	// Project formats replace the built-in ones.
+	if err := f.Define("ticket-id", "#[0-9]+"); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := f.Match("ticket-id", "#12"); !m {
+		t.Error("did not use the project ticket-id format")
+	}
+
+	if err := f.Define("bad", "("); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
@@ -61,6 +61,14 @@ func (s *AllowedDescriptors) Find(key string) *TypedDescriptor {
 	}
 }
 
+// Formats returns the value formats for the "format" value constraints.
+func (s *AllowedDescriptors) Formats() *Formats {
+	if s.formats == nil {
+		s.formats = BuiltinFormats()
+	}
+	return s.formats
+}
+
 func (s *AllowedDescriptors) Type(key string) DescriptorType {
 	t, ok := s.keyTypes[key]
 	if !ok {
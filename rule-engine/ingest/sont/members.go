@@ -0,0 +1,32 @@
+// Under the Apache-2.0 License
+package sont
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/ontology"
+)
+
+const (
+	// MemberCountKey is the meta-descriptor with the number of members in a SOG.
+	MemberCountKey = "$member-count"
+
+	// MemberIdsKey is the meta-descriptor with the id of each member in a SOG.
+	MemberIdsKey = "$member-ids"
+)
+
+//go:embed "members.json"
+var membersSrc []byte
+
+// MemberOntology returns the ontology for the SOG member meta-descriptors.
+func MemberOntology() *ontology.OntologyV1SchemaJson {
+	var ret ontology.OntologyV1SchemaJson
+	dec := json.NewDecoder(bytes.NewReader(membersSrc))
+	if err := dec.Decode(&ret); err != nil {
+		// The embedded file is part of the build, so this is a programming error.
+		panic(err)
+	}
+	return &ret
+}
@@ -8,12 +8,14 @@ import (
 )
 
 type AllowedDescriptors struct {
-	Problems *problem.ProblemSet
-	enums    map[string]*EnumDesc
-	frees    map[string]*FreeDesc
-	numerics map[string]*NumericDesc
-	keyTypes map[string]DescriptorType
-	sources  *sources.SourceGen
+	Problems   *problem.ProblemSet
+	enums      map[string]*EnumDesc
+	frees      map[string]*FreeDesc
+	numerics   map[string]*NumericDesc
+	keyTypes   map[string]DescriptorType
+	keySources map[string][]sources.Source
+	sources    *sources.SourceGen
+	formats    *Formats
 }
 
 type DescriptorType int
@@ -41,10 +43,12 @@ type Descriptor interface {
 type EnumDesc struct {
 	Distinct     bool
 	Enum         map[string]string
+	Values       []string // The enum values as listed, including any duplicates.
 	Key          string
 	MaximumCount int
 	Comments     []string
 	Sources      []sources.Source
+	Origin       sources.Origin
 }
 
 type FreeDesc struct {
@@ -56,6 +60,7 @@ type FreeDesc struct {
 	MaximumCount  int
 	Comments      []string
 	Sources       []sources.Source
+	Origin        sources.Origin
 }
 
 type NumericDesc struct {
@@ -66,6 +71,7 @@ type NumericDesc struct {
 	MaximumCount int
 	Comments     []string
 	Sources      []sources.Source
+	Origin       sources.Origin
 }
 
 type ValueConstraint struct {
@@ -79,11 +85,13 @@ type ValueConstraint struct {
 // New creates a new, shared Descriptors structure.
 func New() *AllowedDescriptors {
 	return &AllowedDescriptors{
-		keyTypes: make(map[string]DescriptorType),
-		enums:    make(map[string]*EnumDesc),
-		frees:    make(map[string]*FreeDesc),
-		numerics: make(map[string]*NumericDesc),
-		Problems: problem.New(),
-		sources:  sources.SourceGenerator(),
+		keyTypes:   make(map[string]DescriptorType),
+		keySources: make(map[string][]sources.Source),
+		enums:      make(map[string]*EnumDesc),
+		frees:      make(map[string]*FreeDesc),
+		numerics:   make(map[string]*NumericDesc),
+		Problems:   problem.New(),
+		sources:    sources.SourceGenerator(),
+		formats:    BuiltinFormats(),
 	}
 }
@@ -9,49 +9,75 @@ import (
 	"github.com/groboclown/qazaar-testing/rule-engine/schema/rules"
 )
 
-func (r *RuleSet) Add(obj *rules.RulesV1SchemaJson) {
+func (r *RuleSet) Add(obj *rules.RulesV1SchemaJson) []sources.MissingRef {
+	return r.AddFile(obj, nil)
+}
+
+// AddFile adds the rules and groups read from the input file.
+//
+// Returns the sources whose ref is not in the file's common source refs.
+func (r *RuleSet) AddFile(obj *rules.RulesV1SchemaJson, input *sources.InputFile) []sources.MissingRef {
 	if obj == nil || r == nil {
-		return
+		return nil
+	}
+	file := ""
+	if input != nil {
+		file = input.Name
 	}
-	prep := r.sources.PrepareRules(&obj.CommonSourceRefs)
-	for _, rule := range obj.Rules {
-		r.addRule(&rule, prep)
+	prep := r.sources.PrepareRules(&obj.CommonSourceRefs, input)
+	for i, rule := range obj.Rules {
+		r.addRule(&rule, prep.Index("rules", i), file)
 	}
-	for _, group := range obj.Groups {
-		r.addGroup(&group, prep)
+	for i, group := range obj.Groups {
+		r.addGroup(&group, prep.Index("groups", i), file)
 	}
+	return prep.MissingRefs()
 }
 
-func (r *RuleSet) addRule(obj *rules.Rule, src *sources.RulesSource) {
+func (r *RuleSet) addRule(obj *rules.Rule, src *sources.RulesSource, file string) {
 	if r == nil || obj == nil {
 		return
 	}
 	s := src.DocumentSources(obj.Sources)
+	vars := joinVariableMap(obj.Variables, src, r.Problems)
+	scope := newVariableScope("rule "+string(obj.Id), vars, r.values, s, r.Problems)
+	obj = scope.rule(obj)
 	r.Rules = append(r.Rules, &Rule{
 		Comments:     comments.JoinRuleComments(obj.Comment, obj.Comments),
 		Sources:      s,
 		Id:           string(obj.Id),
-		Variables:    joinVariableMap(obj.Variables, src, r.Problems),
-		Matchers:     joinMatchers(obj.MatchingDescriptors, src, r.Problems),
+		Variables:    vars,
+		Matchers:     joinMatchers(obj.MatchingDescriptors, src.Field("matchingDescriptors"), r.Problems),
 		Conformities: joinConformities(obj.Conformities, src, r.Problems),
+		Coverages:    joinCoverages(obj.Coverages, src, r.Problems),
+		File:         file,
+		Origin:       src.Origin(),
 	})
+	scope.checkUnused()
 }
 
-func (r *RuleSet) addGroup(obj *rules.Group, src *sources.RulesSource) {
+func (r *RuleSet) addGroup(obj *rules.Group, src *sources.RulesSource, file string) {
 	if r == nil || obj == nil {
 		return
 	}
 	s := src.DocumentSources(obj.Sources)
+	vars := joinVariableMap(obj.Variables, src, r.Problems)
+	scope := newVariableScope("group "+string(obj.Id), vars, r.values, s, r.Problems)
+	obj = scope.group(obj)
 	r.Groups = append(r.Groups, &Group{
 		Comments:        comments.JoinRuleComments(obj.Comment, obj.Comments),
 		Sources:         s,
 		Id:              string(obj.Id),
-		Variables:       joinVariableMap(obj.Variables, src, r.Problems),
-		Matchers:        joinMatchers(obj.MatchingDescriptors, src, r.Problems),
+		Variables:       vars,
+		Matchers:        joinMatchers(obj.MatchingDescriptors, src.Field("matchingDescriptors"), r.Problems),
 		KeySharedValues: joinKeys(obj.SharedValues),
 		Alterations:     joinAlterations(obj.Alterations, src, r.Problems),
 		Convergences:    joinConvergences(obj.Convergences, src, r.Problems),
+		Conformities:    joinConformities(obj.Conformities, src, r.Problems),
+		File:            file,
+		Origin:          src.Origin(),
 	})
+	scope.checkUnused()
 }
 
 func joinKeys(keys []rules.DescriptorKey) []string {
@@ -68,8 +94,9 @@ func joinAlterations(
 	probs *problem.ProblemSet,
 ) []Alteration {
 	ret := make([]Alteration, 0)
-	for _, a := range alts {
-		s := src.DocumentSources(a.Sources)
+	for i, a := range alts {
+		aSrc := src.Index("alterations", i)
+		s := aSrc.DocumentSources(a.Sources)
 		texts, numbers := descriptor.Join(descriptor.DecodeRuleValues(a.Values))
 		ret = append(ret, Alteration{
 			Key:          string(a.Key),
@@ -78,6 +105,7 @@ func joinAlterations(
 			NumberValues: numbers,
 			Comments:     comments.JoinRuleComments(a.Comment, a.Comments),
 			Sources:      s,
+			Origin:       aSrc.Origin(),
 		})
 	}
 	return ret
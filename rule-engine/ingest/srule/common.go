@@ -14,8 +14,8 @@ func joinVariableMap(
 	probs *problem.ProblemSet,
 ) map[string]*VariableDef {
 	ret := make(map[string]*VariableDef)
-	for _, v := range vars {
-		s := src.DocumentSources(v.Sources)
+	for i, v := range vars {
+		s := src.Index("variables", i).DocumentSources(v.Sources)
 		if _, ok := ret[v.Name]; ok {
 			probs.AddWarning(
 				s,
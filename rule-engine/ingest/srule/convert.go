@@ -19,6 +19,16 @@ func toConvergenceType(
 		return AllMatch
 	case rules.ConvergenceImplicationRequiresDisjoint:
 		return Disjoint
+	case rules.ConvergenceImplicationRequiresSubsetOf:
+		return SubsetOf
+	case rules.ConvergenceImplicationRequiresSupersetOf:
+		return SupersetOf
+	case rules.ConvergenceImplicationRequiresAtLeastNDistinct:
+		return AtLeastNDistinct
+	case rules.ConvergenceImplicationRequiresWithinTolerance:
+		return WithinTolerance
+	case rules.ConvergenceImplicationRequiresMajority:
+		return Majority
 	}
 	p.AddError(
 		s,
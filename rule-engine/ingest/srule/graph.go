@@ -0,0 +1,387 @@
+// Under the Apache-2.0 License
+package srule
+
+import (
+	"sort"
+)
+
+// GroupGraph contains the static dependencies between groups.
+//
+// A group depends on another group when the other group's SOG objects can
+// match the group's matchers, either through the other group's alterations or
+// through the values its SOG objects inherit from their members.  The SOG
+// objects from the other group may then join the group's SOG instances, so the
+// other group must be evaluated first.
+//
+// A SOG object inherits every key of every member, and the members can be any
+// object, so only the keys that the other group's alterations set are known not
+// to come from the members.
+type GroupGraph struct {
+	Groups []*Group
+	Deps   map[*Group][]*Group
+
+	// Ignored contains, for each group, the groups whose SOG objects can match
+	// it through inherited values, but which also depend on the group.  Such a
+	// pair has no evaluation order, so the group never sees their SOG objects.
+	// Whether their SOG objects really match is only known once they exist.
+	Ignored map[*Group][]*Group
+}
+
+// NewGroupGraph builds the dependency graph of the groups.
+func NewGroupGraph(groups []*Group) *GroupGraph {
+	ret := &GroupGraph{
+		Groups:  groups,
+		Deps:    make(map[*Group][]*Group),
+		Ignored: make(map[*Group][]*Group),
+	}
+	inherited := make(map[*Group][]*Group)
+	set := make(map[*Group]map[string]bool, len(groups))
+	for _, g := range groups {
+		set[g] = setKeys(g)
+	}
+	for _, g := range groups {
+		deps := make([]*Group, 0)
+		for _, d := range groups {
+			if feedsMatchers(d.Alterations, g.Matchers, false) {
+				deps = append(deps, d)
+			} else if d != g && keysMatch(inheritable(set[d]), g.Matchers) {
+				// A group never sees its own SOG objects, so it can't depend on itself this way.
+				inherited[g] = append(inherited[g], d)
+			}
+		}
+		ret.Deps[g] = deps
+	}
+	ret.addInherited(inherited)
+	return ret
+}
+
+// addInherited adds the dependencies through inherited values, except those in a cycle.
+//
+// Unlike alterations, the members' values only make a group's SOG objects
+// possible candidates, so a cycle through them is not an error; the groups in
+// the cycle just can't see each other's SOG objects.
+func (g *GroupGraph) addInherited(inherited map[*Group][]*Group) {
+	altered := g.Deps
+	g.Deps = make(map[*Group][]*Group, len(altered))
+	for _, x := range g.Groups {
+		g.Deps[x] = append(append(make([]*Group, 0), altered[x]...), inherited[x]...)
+	}
+	component := make(map[*Group]int)
+	for i, scc := range g.components() {
+		for _, x := range scc {
+			component[x] = i
+		}
+	}
+
+	g.Deps = altered
+	for _, x := range g.Groups {
+		for _, d := range inherited[x] {
+			if component[x] == component[d] {
+				g.Ignored[x] = append(g.Ignored[x], d)
+			} else {
+				g.Deps[x] = append(g.Deps[x], d)
+			}
+		}
+	}
+}
+
+// setKeys returns the keys whose values the group's alterations replace.
+//
+// The SOG objects have either no value or the set values for these keys, so
+// the alteration dependencies already cover them.
+func setKeys(g *Group) map[string]bool {
+	ret := make(map[string]bool)
+	for _, a := range g.Alterations {
+		if a.Action == SetAction {
+			ret[a.Key] = true
+		}
+	}
+	return ret
+}
+
+// inheritable returns whether a SOG object may inherit the key from its members.
+func inheritable(set map[string]bool) func(key string) bool {
+	return func(key string) bool {
+		return !set[key]
+	}
+}
+
+// keysMatch checks whether an object that may have the keys may match all the matchers.
+//
+// This only looks at the keys, so the object's values may still not match.
+func keysMatch(has func(key string) bool, m *MatchingDescriptorSet) bool {
+	if m == nil {
+		return true
+	}
+	for _, c := range m.Collection {
+		switch c.Operation {
+		case AndCollection:
+			if !keysMatch(has, c.Matchers) {
+				return false
+			}
+		case OrCollection:
+			if !keysMatchAny(has, c.Matchers) {
+				return false
+			}
+		}
+	}
+	for _, c := range m.Contains {
+		if requiresKey(&c) && !has(c.Key) {
+			return false
+		}
+	}
+	return true
+}
+
+// keysMatchAny checks whether an object that may have the keys may match any of the matchers.
+func keysMatchAny(has func(key string) bool, m *MatchingDescriptorSet) bool {
+	if m == nil {
+		return false
+	}
+	if len(m.Unique) > 0 {
+		return true
+	}
+	for _, c := range m.Collection {
+		var sub MatchingDescriptorSet
+		sub.Collection = []CollectionMatcher{c}
+		if keysMatch(has, &sub) {
+			return true
+		}
+	}
+	for _, c := range m.Contains {
+		if !requiresKey(&c) || has(c.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresKey checks whether an object without the key fails the contains matcher.
+//
+// A count matcher may match zero values, and an each member matcher passes for
+// an object without members.  Otherwise, every operation, including
+// containsOnly, fails for an object without values.
+func requiresKey(c *ContainsMatcher) bool {
+	return !c.Count && c.Members != EachMember && c.Checks.Count() > 0
+}
+
+// feedsMatchers checks whether any of the alterations may change the result of the matchers.
+//
+// A positive contains matcher only depends on an alteration whose values pass
+// one of its checks.  Count matchers, unique matchers, negated matchers, and
+// removals may change the result with any value, so they always depend on an
+// alteration of the key.
+func feedsMatchers(alts []Alteration, m *MatchingDescriptorSet, negated bool) bool {
+	if m == nil {
+		return false
+	}
+	for _, c := range m.Collection {
+		if feedsMatchers(alts, c.Matchers, negated != (c.Operation == NotCollection)) {
+			return true
+		}
+	}
+	for _, c := range m.Contains {
+		for _, a := range alts {
+			if a.Key == c.Key && feedsContains(&a, &c, negated) {
+				return true
+			}
+		}
+	}
+	for _, u := range m.Unique {
+		for _, a := range alts {
+			if a.Key == u.Key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func feedsContains(a *Alteration, c *ContainsMatcher, negated bool) bool {
+	if negated || c.Count || a.Action == RemoveAction || a.Action == RemoveDistinctAction {
+		return true
+	}
+	for _, v := range a.TextValues {
+		for _, t := range c.Checks.Text {
+			if t.Matches(v) {
+				return true
+			}
+		}
+	}
+	for _, v := range a.NumberValues {
+		for _, n := range c.Checks.Numeric {
+			if v >= n.Min && v <= n.Max {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Strata returns the groups in evaluation order.
+//
+// Each group is in a stratum after all the groups it depends on, so all the
+// groups in one stratum can be evaluated together.  Groups in a cycle, and the
+// groups that depend on them, cannot be ordered; they are put into one final
+// stratum.
+func (g *GroupGraph) Strata() [][]*Group {
+	level := make(map[*Group]int)
+	remaining := make([]*Group, len(g.Groups))
+	copy(remaining, g.Groups)
+	for len(remaining) > 0 {
+		next := make([]*Group, 0, len(remaining))
+		for _, x := range remaining {
+			l, ok := g.levelOf(x, level)
+			if ok {
+				level[x] = l
+			} else {
+				next = append(next, x)
+			}
+		}
+		if len(next) == len(remaining) {
+			break
+		}
+		remaining = next
+	}
+
+	ret := make([][]*Group, 0)
+	for _, x := range g.Groups {
+		l, ok := level[x]
+		if !ok {
+			continue
+		}
+		for len(ret) <= l {
+			ret = append(ret, make([]*Group, 0))
+		}
+		ret[l] = append(ret[l], x)
+	}
+	if len(remaining) > 0 {
+		ret = append(ret, remaining)
+	}
+	return ret
+}
+
+// levelOf returns the stratum of the group, if all its dependencies have a stratum.
+func (g *GroupGraph) levelOf(x *Group, level map[*Group]int) (int, bool) {
+	ret := 0
+	for _, d := range g.Deps[x] {
+		l, ok := level[d]
+		if !ok {
+			return 0, false
+		}
+		if l+1 > ret {
+			ret = l + 1
+		}
+	}
+	return ret, true
+}
+
+// Cycles returns each dependency cycle as the path of groups, starting and ending with the same group.
+//
+// A group whose matchers use a key that it alters is a cycle of one group.
+func (g *GroupGraph) Cycles() [][]*Group {
+	ret := make([][]*Group, 0)
+	for _, scc := range g.components() {
+		start := scc[0]
+		if len(scc) == 1 && !g.dependsOn(start, start) {
+			continue
+		}
+		in := make(map[*Group]bool, len(scc))
+		for _, x := range scc {
+			in[x] = true
+		}
+		ret = append(ret, g.cyclePath(start, in))
+	}
+	return ret
+}
+
+func (g *GroupGraph) dependsOn(a, b *Group) bool {
+	for _, d := range g.Deps[a] {
+		if d == b {
+			return true
+		}
+	}
+	return false
+}
+
+// cyclePath finds a path from the group back to itself, only visiting groups in the component.
+func (g *GroupGraph) cyclePath(start *Group, in map[*Group]bool) []*Group {
+	visited := make(map[*Group]bool)
+	var walk func(curr *Group, path []*Group) []*Group
+	walk = func(curr *Group, path []*Group) []*Group {
+		for _, d := range sortedGroups(g.Deps[curr]) {
+			if d == start {
+				return append(path, d)
+			}
+			if in[d] && !visited[d] {
+				visited[d] = true
+				if ret := walk(d, append(path, d)); ret != nil {
+					return ret
+				}
+			}
+		}
+		return nil
+	}
+	path := walk(start, []*Group{start})
+	// The path follows the dependencies, which is the reverse of the evaluation order.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// components returns the strongly connected components, using Tarjan's algorithm.
+//
+// Each component is sorted by group id, and the components are sorted by their first group id.
+func (g *GroupGraph) components() [][]*Group {
+	index := make(map[*Group]int)
+	low := make(map[*Group]int)
+	onStack := make(map[*Group]bool)
+	stack := make([]*Group, 0)
+	ret := make([][]*Group, 0)
+	next := 0
+
+	var connect func(v *Group)
+	connect = func(v *Group) {
+		index[v] = next
+		low[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range g.Deps[v] {
+			if _, ok := index[w]; !ok {
+				connect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] == index[v] {
+			scc := make([]*Group, 0)
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			ret = append(ret, sortedGroups(scc))
+		}
+	}
+	for _, v := range g.Groups {
+		if _, ok := index[v]; !ok {
+			connect(v)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i][0].Id < ret[j][0].Id })
+	return ret
+}
+
+func sortedGroups(groups []*Group) []*Group {
+	ret := make([]*Group, len(groups))
+	copy(ret, groups)
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Id < ret[j].Id })
+	return ret
+}
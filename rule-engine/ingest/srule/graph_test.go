@@ -0,0 +1,174 @@
+// Under the Apache-2.0 License
+package srule_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+const graphRules = `{
+	"$schema": "",
+	"groups": [
+		{
+			"id": "structure",
+			"sharedValues": ["structure"],
+			"matchingDescriptors": [{"key": "sog-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}],
+			"alterations": [{"key": "sog-type", "action": "set", "values": ["structure"]}]
+		},
+		{
+			"id": "field",
+			"sharedValues": ["structure", "name"],
+			"matchingDescriptors": [{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}],
+			"alterations": [{"key": "sog-type", "action": "set", "values": ["field"]}]
+		},
+		{
+			"id": "name",
+			"sharedValues": ["name"],
+			"matchingDescriptors": [{"key": "data-type", "type": "containsExactly", "values": [{"type": "equal", "text": "field"}]}],
+			"alterations": [{"key": "tag", "action": "add", "values": ["named"]}]
+		},
+		{
+			"id": "loop-a",
+			"sharedValues": ["name"],
+			"matchingDescriptors": [{"key": "tag", "type": "containsSome", "values": [{"type": "equal", "text": "b"}]}],
+			"alterations": [{"key": "tag", "action": "add", "values": ["a"]}]
+		},
+		{
+			"id": "loop-b",
+			"sharedValues": ["name"],
+			"matchingDescriptors": [{"key": "tag", "type": "containsSome", "values": [{"type": "equal", "text": "a"}]}],
+			"alterations": [{"key": "tag", "action": "add", "values": ["b"]}]
+		},
+		{
+			"id": "self",
+			"sharedValues": ["name"],
+			"matchingDescriptors": [{"type": "not", "matcher": {"key": "priority", "type": "containsSome", "values": [{"type": "equal", "text": "low"}]}}],
+			"alterations": [{"key": "priority", "action": "set", "values": ["high"]}]
+		},
+		{
+			"id": "unique",
+			"sharedValues": ["name"],
+			"matchingDescriptors": [{"key": "priority", "type": "unique"}]
+		}
+	]
+}`
+
+func Test_GroupGraph(t *testing.T) {
+	rs := addVariableRules(t, graphRules, nil)
+	if rs.Problems.HasProblems() {
+		t.Fatal(rs.Problems.Problems())
+	}
+	graph := srule.NewGroupGraph(rs.Groups)
+
+	strata := make([][]string, 0)
+	for _, s := range graph.Strata() {
+		strata = append(strata, groupIds(s))
+	}
+	expected := [][]string{
+		{"field", "name"},
+		{"structure"},
+		{"loop-a", "loop-b", "self", "unique"},
+	}
+	if diff := cmp.Diff(expected, strata); diff != "" {
+		t.Errorf("strata mismatch (-want +got):\n%s", diff)
+	}
+
+	cycles := make([][]string, 0)
+	for _, c := range graph.Cycles() {
+		cycles = append(cycles, groupIds(c))
+	}
+	expected = [][]string{
+		{"loop-a", "loop-b", "loop-a"},
+		{"self", "self"},
+	}
+	if diff := cmp.Diff(expected, cycles); diff != "" {
+		t.Errorf("cycle mismatch (-want +got):\n%s", diff)
+	}
+
+	// A SOG object carries all of its members' keys, so every group's SOG
+	// objects may match the others, except where an alteration sets the key
+	// that the matchers need.  The structure group requires the sog-type that
+	// the field group sets, so it can't see the field group's SOG objects
+	// through their members.  Every other pair of groups depends on each
+	// other, so neither can go first.
+	ignored := make(map[string][]string)
+	for g, deps := range graph.Ignored {
+		ignored[g.Id] = groupIds(deps)
+	}
+	expectedIgnored := map[string][]string{
+		"structure": {"name", "loop-a", "loop-b", "self", "unique"},
+		"field":     {"structure", "name", "loop-a", "loop-b", "self", "unique"},
+		"name":      {"structure", "field", "loop-a", "loop-b", "self", "unique"},
+		"loop-a":    {"structure", "field", "name", "self", "unique"},
+		"loop-b":    {"structure", "field", "self", "unique"},
+		"self":      {"structure", "field", "name", "loop-a", "loop-b", "unique"},
+		"unique":    {"structure", "field", "name", "loop-a", "loop-b"},
+	}
+	if diff := cmp.Diff(expectedIgnored, ignored); diff != "" {
+		t.Errorf("ignored mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_GroupGraph_Inherited(t *testing.T) {
+	// Each group sets the keys that the other groups' matchers need, except
+	// for the owner key, which the owned group's SOG objects can only inherit
+	// from the fields group's members.
+	rs := addVariableRules(t, `{
+		"$schema": "",
+		"groups": [
+			{
+				"id": "fields",
+				"sharedValues": ["structure"],
+				"matchingDescriptors": [{"key": "data-type", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]}],
+				"alterations": [{"key": "data-type", "action": "set", "values": ["structure"]}]
+			},
+			{
+				"id": "owned",
+				"sharedValues": ["structure"],
+				"matchingDescriptors": [{"key": "owner", "type": "containsOnly", "values": [{"type": "equal", "text": "alice"}]}],
+				"alterations": [
+					{"key": "data-type", "action": "set", "values": ["owned"]},
+					{"key": "owner", "action": "set", "values": ["group"]}
+				]
+			},
+			{
+				"id": "sizes",
+				"sharedValues": ["size-class"],
+				"matchingDescriptors": [{"key": "$member-count", "type": "containsSome", "values": [{"type": "within", "minimum": 2, "maximum": 100}]}],
+				"alterations": [
+					{"key": "data-type", "action": "set", "values": ["sizes"]},
+					{"key": "owner", "action": "set", "values": ["sizes"]}
+				]
+			}
+		]
+	}`, nil)
+	if rs.Problems.HasProblems() {
+		t.Fatal(rs.Problems.Problems())
+	}
+	graph := srule.NewGroupGraph(rs.Groups)
+	deps := make(map[string][]string)
+	for g, d := range graph.Deps {
+		deps[g.Id] = groupIds(d)
+	}
+	expected := map[string][]string{
+		"fields": {},
+		"owned":  {"fields"},
+		"sizes":  {"fields", "owned"},
+	}
+	if diff := cmp.Diff(expected, deps); diff != "" {
+		t.Errorf("dependency mismatch (-want +got):\n%s", diff)
+	}
+	if len(graph.Ignored) != 0 {
+		t.Errorf("unexpected ignored dependencies: %v", graph.Ignored)
+	}
+}
+
+func groupIds(groups []*srule.Group) []string {
+	ret := make([]string, len(groups))
+	for i, g := range groups {
+		ret[i] = g.Id
+	}
+	return ret
+}
@@ -14,7 +14,8 @@ func joinConformities(
 	probs *problem.ProblemSet,
 ) []LeveledMatcher {
 	byLevel := make(map[rules.ImplicationLevel]*LeveledMatcher)
-	for _, c := range conf {
+	for i, c := range conf {
+		cSrc := src.Index("conformities", i)
 		m, ok := byLevel[c.Level]
 		if !ok {
 			m = &LeveledMatcher{
@@ -22,13 +23,14 @@ func joinConformities(
 				Matchers: &MatchingDescriptorSet{
 					Collection: make([]CollectionMatcher, 0),
 					Contains:   make([]ContainsMatcher, 0),
+					Unique:     make([]UniqueMatcher, 0),
 				},
 				Comments: comments.JoinRuleComments(c.Comment, c.Comments),
-				Sources:  src.DocumentSources(c.Sources),
+				Sources:  cSrc.DocumentSources(c.Sources),
 			}
 			byLevel[c.Level] = m
 		}
-		addConformity(m, &c, src, probs)
+		addConformity(m, &c, cSrc, probs)
 	}
 
 	ret := make([]LeveledMatcher, len(byLevel))
@@ -49,7 +51,7 @@ func addConformity(
 	if m == nil || conf == nil {
 		return
 	}
-	addMatcher(m.Matchers, &conf.Matcher, src, probs)
+	addMatcher(m.Matchers, &conf.Matcher, src.Field("matcher"), probs)
 }
 
 func joinConvergences(
@@ -58,15 +60,92 @@ func joinConvergences(
 	probs *problem.ProblemSet,
 ) []Convergence {
 	ret := make([]Convergence, 0)
-	for _, c := range conv {
-		s := src.DocumentSources(c.Sources)
+	for i, c := range conv {
+		cSrc := src.Index("convergences", i)
+		s := cSrc.DocumentSources(c.Sources)
 		ret = append(ret, Convergence{
-			Key:      string(c.Key),
-			Level:    string(c.Level),
-			Distinct: c.Distinct,
-			Requires: toConvergenceType(c.Requires, s, probs),
-			Comments: comments.JoinRuleComments(c.Comment, c.Comments),
-			Sources:  s,
+			Key:       string(c.Key),
+			Level:     string(c.Level),
+			Distinct:  c.Distinct,
+			Requires:  toConvergenceType(c.Requires, s, probs),
+			Reference: joinMatchers(c.Reference, cSrc.Field("reference"), probs),
+			Count:     convergenceCount(&c, s, probs),
+			Tolerance: convergenceTolerance(&c, s, probs),
+			Comments:  comments.JoinRuleComments(c.Comment, c.Comments),
+			Sources:   s,
+			Origin:    cSrc.Origin(),
+		})
+	}
+	return ret
+}
+
+// convergenceCount returns the count for the convergence, which the atLeastNDistinct type requires.
+func convergenceCount(
+	c *rules.ConvergenceImplication,
+	s []sources.Source,
+	probs *problem.ProblemSet,
+) int {
+	if c.Count != nil {
+		return *c.Count
+	}
+	if c.Requires == rules.ConvergenceImplicationRequiresAtLeastNDistinct {
+		probs.AddError(
+			s,
+			"convergence %s for %s requires a count",
+			c.Requires,
+			c.Key,
+		)
+	}
+	return 1
+}
+
+// convergenceTolerance returns the tolerance for the convergence, which the withinTolerance type requires.
+func convergenceTolerance(
+	c *rules.ConvergenceImplication,
+	s []sources.Source,
+	probs *problem.ProblemSet,
+) float64 {
+	if c.Tolerance != nil {
+		return *c.Tolerance
+	}
+	if c.Requires == rules.ConvergenceImplicationRequiresWithinTolerance {
+		probs.AddError(
+			s,
+			"convergence %s for %s requires a tolerance",
+			c.Requires,
+			c.Key,
+		)
+	}
+	return 0
+}
+
+func joinCoverages(
+	cov []rules.CoverageImplication,
+	src *sources.RulesSource,
+	probs *problem.ProblemSet,
+) []Coverage {
+	ret := make([]Coverage, 0)
+	for i, c := range cov {
+		cSrc := src.Index("coverages", i)
+		s := cSrc.DocumentSources(c.Sources)
+		minimum := c.Minimum
+		if minimum < 1 {
+			probs.AddError(
+				s,
+				"coverage minimum for %s must be at least 1 (%d)",
+				c.Key,
+				c.Minimum,
+			)
+			minimum = 1
+		}
+		ret = append(ret, Coverage{
+			Key:         string(c.Key),
+			Level:       string(c.Level),
+			Counterpart: joinMatchers(c.Counterpart, cSrc.Field("counterpart"), probs),
+			Minimum:     minimum,
+			Comments:    comments.JoinRuleComments(c.Comment, c.Comments),
+			Sources:     s,
+			Origin:      cSrc.Origin(),
 		})
 	}
 	return ret
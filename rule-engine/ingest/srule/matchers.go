@@ -3,6 +3,7 @@ package srule
 
 import (
 	"regexp"
+	"strconv"
 
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/internal/sel"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
@@ -11,6 +12,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+// joinMatchers converts the matchers, which src locates as the matcher list.
 func joinMatchers[T rules.MatchingDescriptor | rules.NotMatcherMatcher](
 	matchers []T,
 	src *sources.RulesSource,
@@ -19,9 +21,10 @@ func joinMatchers[T rules.MatchingDescriptor | rules.NotMatcherMatcher](
 	ret := MatchingDescriptorSet{
 		Collection: make([]CollectionMatcher, 0),
 		Contains:   make([]ContainsMatcher, 0),
+		Unique:     make([]UniqueMatcher, 0),
 	}
-	for _, m := range matchers {
-		addMatcher(&ret, &m, src, probs)
+	for i, m := range matchers {
+		addMatcher(&ret, &m, src.Field(strconv.Itoa(i)), probs)
 	}
 	return &ret
 }
@@ -58,37 +61,51 @@ func addMatcher[
 				if err != nil {
 					return err
 				}
+				not := joinMatchers([]rules.NotMatcherMatcher{}, src, probs)
+				addMatcher(not, &match.Matcher, src.Field("matcher"), probs)
 				m.Collection = append(m.Collection, CollectionMatcher{
 					Operation: NotCollection,
-					Matchers:  joinMatchers([]rules.NotMatcherMatcher{match.Matcher}, src, probs),
+					Matchers:  not,
+					Origin:    src.Origin(),
 				})
 				return nil
 			},
 			string(rules.ContainsMatcherTypeContainsSome): func(val map[string]any) error {
 				return newContainsMatcher(
-					m, ContainsSome, val, probs,
+					m, ContainsSome, val, src, probs,
 				)
 			},
 			string(rules.ContainsMatcherTypeContainsAll): func(val map[string]any) error {
 				return newContainsMatcher(
-					m, ContainsAll, val, probs,
+					m, ContainsAll, val, src, probs,
 				)
 			},
 			string(rules.ContainsMatcherTypeContainsExactly): func(val map[string]any) error {
 				return newContainsMatcher(
-					m, ContainsExactly, val, probs,
+					m, ContainsExactly, val, src, probs,
 				)
 			},
 			string(rules.ContainsMatcherTypeContainsOnly): func(val map[string]any) error {
 				return newContainsMatcher(
-					m, ContainsOnly, val, probs,
+					m, ContainsOnly, val, src, probs,
 				)
 			},
+			string(rules.UniqueMatcherTypeUnique): func(val map[string]any) error {
+				var match rules.UniqueMatcher
+				err := mapstructure.Decode(val, &match)
+				if err != nil {
+					return err
+				}
+				m.Unique = append(m.Unique, UniqueMatcher{Key: string(match.Key), Origin: src.Origin()})
+				return nil
+			},
 		},
 	)
 	if err != nil {
-		probs.AddError(
+		probs.AddProblemAt(
+			src.Origin(),
 			nil,
+			problem.Err,
 			"error decoding matcher: %s",
 			err.Error(),
 		)
@@ -109,7 +126,8 @@ func newCollectionMatcher(
 	}
 	m.Collection = append(m.Collection, CollectionMatcher{
 		Operation: operation,
-		Matchers:  joinMatchers(match.Collection, src, probs),
+		Matchers:  joinMatchers(match.Collection, src.Field("collection"), probs),
+		Origin:    src.Origin(),
 	})
 	return nil
 }
@@ -118,6 +136,7 @@ func newContainsMatcher(
 	m *MatchingDescriptorSet,
 	operation ContainsOperation,
 	val map[string]any,
+	src *sources.RulesSource,
 	probs *problem.ProblemSet,
 ) error {
 	var match rules.ContainsMatcher
@@ -129,21 +148,37 @@ func newContainsMatcher(
 		Operation: operation,
 		Count:     match.Count,
 		Distinct:  match.Distinct,
+		Members:   memberSelection(match.Members),
 		Key:       string(match.Key),
-		Checks:    joinChecks(match.Values, probs),
+		Checks:    joinChecks(match.Values, src.Field("values"), probs),
+		Origin:    src.Origin(),
 	})
 	return nil
 }
 
+func memberSelection(members *rules.ContainsMatcherMembers) MemberSelection {
+	if members == nil {
+		return ObjectValues
+	}
+	switch *members {
+	case rules.ContainsMatcherMembersUnion:
+		return UnionMembers
+	case rules.ContainsMatcherMembersEach:
+		return EachMember
+	}
+	return ObjectValues
+}
+
 func joinChecks(
 	checks rules.ValueCheckList,
+	src *sources.RulesSource,
 	probs *problem.ProblemSet,
 ) ValueCheckSet {
 	ret := &ValueCheckSet{
 		Text:    make([]StringCheck, 0),
 		Numeric: make([]NumericBoundsCheck, 0),
 	}
-	for _, c := range checks {
+	for i, c := range checks {
 		err := sel.TypeSelector(
 			c, "type", sel.SelectHandlerMap{
 				string(rules.StringCheckTypeEqual): func(val map[string]any) error {
@@ -158,8 +193,10 @@ func joinChecks(
 			},
 		)
 		if err != nil {
-			probs.AddError(
+			probs.AddProblemAt(
+				src.Field(strconv.Itoa(i)).Origin(),
 				nil,
+				problem.Err,
 				"error decoding value check: %s",
 				err.Error(),
 			)
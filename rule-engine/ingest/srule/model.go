@@ -14,6 +14,7 @@ type RuleSet struct {
 	Groups   []*Group
 	Problems *problem.ProblemSet
 	sources  *sources.SourceGen
+	values   VariableValues
 }
 
 type Rule struct {
@@ -21,8 +22,11 @@ type Rule struct {
 	Variables    map[string]*VariableDef
 	Matchers     *MatchingDescriptorSet
 	Conformities []LeveledMatcher
+	Coverages    []Coverage
 	Comments     []string
 	Sources      []sources.Source
+	File         string // Rule file containing the definition, if known.
+	Origin       sources.Origin
 }
 
 type Group struct {
@@ -32,8 +36,11 @@ type Group struct {
 	KeySharedValues []string
 	Alterations     []Alteration
 	Convergences    []Convergence
+	Conformities    []LeveledMatcher // Checked against each sealed SOG object.
 	Comments        []string
 	Sources         []sources.Source
+	File            string // Rule file containing the definition, if known.
+	Origin          sources.Origin
 }
 
 type ConvergenceType int
@@ -41,15 +48,42 @@ type ConvergenceType int
 const (
 	AllMatch ConvergenceType = iota
 	Disjoint
+	SubsetOf
+	SupersetOf
+	AtLeastNDistinct
+	WithinTolerance
+	Majority
 )
 
+// Convergence requires the SOG members' values for the key to align.
+//
+// Reference is only used by SubsetOf and SupersetOf, Count by AtLeastNDistinct,
+// and Tolerance by WithinTolerance.
 type Convergence struct {
-	Key      string
-	Level    string
-	Distinct bool
-	Requires ConvergenceType
-	Comments []string
-	Sources  []sources.Source
+	Key       string
+	Level     string
+	Distinct  bool
+	Requires  ConvergenceType
+	Reference *MatchingDescriptorSet
+	Count     int
+	Tolerance float64
+	Comments  []string
+	Sources   []sources.Source
+	Origin    sources.Origin
+}
+
+// Coverage requires that other objects cover each of the matching object's values for the key.
+//
+// A counterpart object covers a value if it matches the counterpart matchers and
+// contains that value for the key.
+type Coverage struct {
+	Key         string
+	Level       string
+	Counterpart *MatchingDescriptorSet
+	Minimum     int
+	Comments    []string
+	Sources     []sources.Source
+	Origin      sources.Origin
 }
 
 type AlterationAction int
@@ -69,6 +103,7 @@ type Alteration struct {
 	NumberValues []float64
 	Comments     []string
 	Sources      []sources.Source
+	Origin       sources.Origin
 }
 
 type LeveledMatcher struct {
@@ -81,6 +116,7 @@ type LeveledMatcher struct {
 type MatchingDescriptorSet struct {
 	Collection []CollectionMatcher
 	Contains   []ContainsMatcher
+	Unique     []UniqueMatcher
 }
 
 type CollectionOperation int
@@ -94,6 +130,7 @@ const (
 type CollectionMatcher struct {
 	Operation CollectionOperation
 	Matchers  *MatchingDescriptorSet
+	Origin    sources.Origin
 }
 
 type ContainsOperation int
@@ -105,12 +142,32 @@ const (
 	ContainsExactly
 )
 
+// MemberSelection selects which values a contains matcher checks on a SOG object.
+type MemberSelection int
+
+const (
+	// ObjectValues checks the object's own values.
+	ObjectValues MemberSelection = iota
+	// UnionMembers checks the distinct values across all the members.
+	UnionMembers
+	// EachMember checks each member's values; every member must match.
+	EachMember
+)
+
 type ContainsMatcher struct {
 	Operation ContainsOperation
 	Count     bool
 	Distinct  bool
+	Members   MemberSelection
 	Key       string
 	Checks    ValueCheckSet
+	Origin    sources.Origin
+}
+
+// UniqueMatcher matches when no two members of the object share a value for the key.
+type UniqueMatcher struct {
+	Key    string
+	Origin sources.Origin
 }
 
 type ValueCheckSet struct {
@@ -149,6 +206,9 @@ type NumericBoundsCheck struct {
 	Max float64
 }
 
+// VariableValues maps the variable name to its replacement text.
+type VariableValues map[string]string
+
 type VariableDef struct {
 	Comments    []string
 	Description *string
@@ -163,5 +223,17 @@ func New() *RuleSet {
 		Groups:   make([]*Group, 0),
 		Problems: problem.New(),
 		sources:  sources.SourceGenerator(),
+		values:   make(VariableValues),
+	}
+}
+
+// UseVariables sets the variable values to replace in the rules and groups added afterwards.
+func (r *RuleSet) UseVariables(values VariableValues) {
+	if r == nil {
+		return
+	}
+	r.values = make(VariableValues)
+	for k, v := range values {
+		r.values[k] = v
 	}
 }
@@ -0,0 +1,257 @@
+// Under the Apache-2.0 License
+package srule
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/rules"
+)
+
+const (
+	TextVariableType    = "text"
+	NumberVariableType  = "number"
+	IntegerVariableType = "integer"
+)
+
+var variableRef = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// variableScope replaces the variable references within a single rule or group.
+//
+// The replacement happens on the raw schema values, before the matchers decode
+// them, so that a variable may stand in for a numeric bound as well as text.
+type variableScope struct {
+	owner  string
+	defs   map[string]*VariableDef
+	values VariableValues
+	used   map[string]bool
+	missed map[string]bool
+	src    []sources.Source
+	probs  *problem.ProblemSet
+}
+
+func newVariableScope(
+	owner string,
+	defs map[string]*VariableDef,
+	values VariableValues,
+	src []sources.Source,
+	probs *problem.ProblemSet,
+) *variableScope {
+	ret := &variableScope{
+		owner:  owner,
+		defs:   defs,
+		values: values,
+		used:   make(map[string]bool),
+		missed: make(map[string]bool),
+		src:    src,
+		probs:  probs,
+	}
+	ret.checkValues()
+	return ret
+}
+
+// checkValues ensures the supplied values match the declared variable types.
+func (v *variableScope) checkValues() {
+	for name, d := range v.defs {
+		val, ok := v.values[name]
+		if !ok {
+			continue
+		}
+		switch d.Type {
+		case NumberVariableType:
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				v.probs.AddError(
+					sources.Join(v.src, d.Sources...),
+					"%s: variable '%s' requires a number value (%s)",
+					v.owner,
+					name,
+					val,
+				)
+			}
+		case IntegerVariableType:
+			if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+				v.probs.AddError(
+					sources.Join(v.src, d.Sources...),
+					"%s: variable '%s' requires an integer value (%s)",
+					v.owner,
+					name,
+					val,
+				)
+			}
+		}
+	}
+}
+
+// checkUnused reports the declared variables that the definition never referenced.
+func (v *variableScope) checkUnused() {
+	for name, d := range v.defs {
+		if !v.used[name] {
+			v.probs.AddWarning(
+				sources.Join(v.src, d.Sources...),
+				"%s: variable '%s' declared but not used",
+				v.owner,
+				name,
+			)
+		}
+	}
+}
+
+// text replaces every variable reference in the string with the variable's value.
+func (v *variableScope) text(s string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return variableRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+		if val, ok := v.lookup(name); ok {
+			return val
+		}
+		return ref
+	})
+}
+
+// token replaces the variable references in the raw value.
+//
+// If the value is a single reference to a numeric variable, then this returns
+// the number, so that numeric fields decode correctly.
+func (v *variableScope) token(s string) any {
+	m := variableRef.FindStringSubmatchIndex(s)
+	if m != nil && m[0] == 0 && m[1] == len(s) {
+		name := s[m[2]:m[3]]
+		d := v.defs[name]
+		if d != nil && (d.Type == NumberVariableType || d.Type == IntegerVariableType) {
+			if val, ok := v.lookup(name); ok {
+				if n, err := strconv.ParseFloat(val, 64); err == nil {
+					return n
+				}
+				return val
+			}
+			return s
+		}
+	}
+	return v.text(s)
+}
+
+// lookup finds the value for the variable, reporting each missing variable once.
+func (v *variableScope) lookup(name string) (string, bool) {
+	d, ok := v.defs[name]
+	if !ok {
+		if !v.missed[name] {
+			v.missed[name] = true
+			v.probs.AddError(
+				v.src,
+				"%s: reference to undefined variable '%s'",
+				v.owner,
+				name,
+			)
+		}
+		return "", false
+	}
+	v.used[name] = true
+	val, ok := v.values[name]
+	if !ok {
+		if !v.missed[name] {
+			v.missed[name] = true
+			v.probs.AddError(
+				sources.Join(v.src, d.Sources...),
+				"%s: no value provided for variable '%s'",
+				v.owner,
+				name,
+			)
+		}
+		return "", false
+	}
+	return val, true
+}
+
+// raw replaces the variable references within a decoded JSON structure.
+//
+// This returns a copy of the structure, leaving the original untouched.
+// Meta-data keys (those starting with '$', such as comments) are not replaced.
+func (v *variableScope) raw(val any) any {
+	switch t := val.(type) {
+	case string:
+		return v.token(t)
+	case map[string]any:
+		ret := make(map[string]any, len(t))
+		for k, x := range t {
+			if strings.HasPrefix(k, "$") {
+				ret[k] = x
+			} else {
+				ret[k] = v.raw(x)
+			}
+		}
+		return ret
+	case []any:
+		ret := make([]any, len(t))
+		for i, x := range t {
+			ret[i] = v.raw(x)
+		}
+		return ret
+	}
+	return val
+}
+
+func (v *variableScope) matchers(m rules.MatcherCollection) rules.MatcherCollection {
+	if m == nil {
+		return nil
+	}
+	ret := make(rules.MatcherCollection, len(m))
+	for i, x := range m {
+		ret[i] = v.raw(x)
+	}
+	return ret
+}
+
+// rule returns a copy of the rule with the variables replaced.
+func (v *variableScope) rule(obj *rules.Rule) *rules.Rule {
+	ret := *obj
+	ret.MatchingDescriptors = v.matchers(obj.MatchingDescriptors)
+	ret.Conformities = make([]rules.ConformityImplication, len(obj.Conformities))
+	for i, c := range obj.Conformities {
+		c.Matcher = v.raw(c.Matcher)
+		ret.Conformities[i] = c
+	}
+	ret.Coverages = make([]rules.CoverageImplication, len(obj.Coverages))
+	for i, c := range obj.Coverages {
+		c.Key = rules.DescriptorKey(v.text(string(c.Key)))
+		c.Counterpart = v.matchers(c.Counterpart)
+		ret.Coverages[i] = c
+	}
+	return &ret
+}
+
+// group returns a copy of the group with the variables replaced.
+func (v *variableScope) group(obj *rules.Group) *rules.Group {
+	ret := *obj
+	ret.MatchingDescriptors = v.matchers(obj.MatchingDescriptors)
+	ret.SharedValues = make([]rules.DescriptorKey, len(obj.SharedValues))
+	for i, k := range obj.SharedValues {
+		ret.SharedValues[i] = rules.DescriptorKey(v.text(string(k)))
+	}
+	ret.Alterations = make([]rules.Alteration, len(obj.Alterations))
+	for i, a := range obj.Alterations {
+		a.Key = rules.DescriptorKey(v.text(string(a.Key)))
+		vals := make([]rules.AlterationValuesElem, len(a.Values))
+		for j, x := range a.Values {
+			vals[j] = v.raw(x)
+		}
+		a.Values = vals
+		ret.Alterations[i] = a
+	}
+	ret.Convergences = make([]rules.ConvergenceImplication, len(obj.Convergences))
+	for i, c := range obj.Convergences {
+		c.Key = rules.DescriptorKey(v.text(string(c.Key)))
+		c.Reference = v.matchers(c.Reference)
+		ret.Convergences[i] = c
+	}
+	ret.Conformities = make([]rules.ConformityImplication, len(obj.Conformities))
+	for i, c := range obj.Conformities {
+		c.Matcher = v.raw(c.Matcher)
+		ret.Conformities[i] = c
+	}
+	return &ret
+}
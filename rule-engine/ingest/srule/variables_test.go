@@ -0,0 +1,120 @@
+// Under the Apache-2.0 License
+package srule_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/rules"
+)
+
+const variableRules = `{
+	"$schema": "",
+	"rules": [{
+		"id": "r1",
+		"variables": [
+			{"name": "kind", "type": "text"},
+			{"name": "max", "type": "integer"}
+		],
+		"matchingDescriptors": [
+			{"key": "data-type", "type": "containsSome", "values": [{"type": "equal", "text": "${kind}"}]}
+		],
+		"conformities": [{
+			"level": "error",
+			"matcher": {
+				"key": "size",
+				"type": "containsAll",
+				"values": [{"type": "within", "minimum": 0, "maximum": "${max}"}]
+			}
+		}]
+	}],
+	"groups": [{
+		"id": "g1",
+		"variables": [
+			{"name": "kind", "type": "text"},
+			{"name": "extra", "type": "text"}
+		],
+		"sharedValues": ["name"],
+		"alterations": [{"key": "sog-type", "action": "set", "values": ["${kind}-sog"]}]
+	}]
+}`
+
+func Test_Variables(t *testing.T) {
+	t.Run("replaced", func(t *testing.T) {
+		rs := addVariableRules(t, variableRules, srule.VariableValues{"kind": "field", "max": "20", "extra": "x"})
+		if diff := problemMessages(rs.Problems, problem.Err); diff != "" {
+			t.Errorf("unexpected errors: %s", diff)
+		}
+
+		r := rs.Rules[0]
+		if len(r.Matchers.Contains) != 1 || len(r.Matchers.Contains[0].Checks.Text) != 1 {
+			t.Fatalf("bad matchers: %v", r.Matchers)
+		}
+		if !r.Matchers.Contains[0].Checks.Text[0].Matches("field") {
+			t.Errorf("text variable not replaced: %s", r.Matchers.Contains[0].Checks.Text[0].R.String())
+		}
+		if len(r.Conformities) != 1 || len(r.Conformities[0].Matchers.Contains) != 1 {
+			t.Fatalf("bad conformities: %v", r.Conformities)
+		}
+		bounds := r.Conformities[0].Matchers.Contains[0].Checks.Numeric
+		if len(bounds) != 1 || bounds[0].Max != 20 {
+			t.Errorf("numeric variable not replaced: %v", bounds)
+		}
+
+		g := rs.Groups[0]
+		if len(g.Alterations) != 1 || len(g.Alterations[0].TextValues) != 1 || g.Alterations[0].TextValues[0] != "field-sog" {
+			t.Errorf("alteration variable not replaced: %v", g.Alterations)
+		}
+		if !strings.Contains(problemMessages(rs.Problems, problem.Warn), "'extra' declared but not used") {
+			t.Errorf("expected unused variable warning, found %v", rs.Problems.Problems())
+		}
+	})
+	t.Run("missing-value", func(t *testing.T) {
+		rs := addVariableRules(t, variableRules, srule.VariableValues{"kind": "field"})
+		if !strings.Contains(problemMessages(rs.Problems, problem.Err), "no value provided for variable 'max'") {
+			t.Errorf("expected missing value error, found %v", rs.Problems.Problems())
+		}
+	})
+	t.Run("bad-type", func(t *testing.T) {
+		rs := addVariableRules(t, variableRules, srule.VariableValues{"kind": "field", "max": "2.5"})
+		if !strings.Contains(problemMessages(rs.Problems, problem.Err), "variable 'max' requires an integer value") {
+			t.Errorf("expected type error, found %v", rs.Problems.Problems())
+		}
+	})
+	t.Run("undefined", func(t *testing.T) {
+		rs := addVariableRules(t, `{
+			"$schema": "",
+			"rules": [{
+				"id": "r2",
+				"matchingDescriptors": [
+					{"key": "${key}", "type": "containsSome", "values": [{"type": "equal", "text": "a"}]}
+				]
+			}]
+		}`, srule.VariableValues{"key": "k"})
+		if !strings.Contains(problemMessages(rs.Problems, problem.Err), "reference to undefined variable 'key'") {
+			t.Errorf("expected undefined variable error, found %v", rs.Problems.Problems())
+		}
+	})
+}
+
+func addVariableRules(t *testing.T, src string, values srule.VariableValues) *srule.RuleSet {
+	var r rules.RulesV1SchemaJson
+	if err := json.Unmarshal([]byte(src), &r); err != nil {
+		t.Fatal(err)
+	}
+	rs := srule.New()
+	rs.UseVariables(values)
+	rs.Add(&r)
+	return rs
+}
+
+func problemMessages(probs *problem.ProblemSet, level problem.ProblemLevel) string {
+	msgs := make([]string, 0)
+	for _, p := range probs.ProblemsAt(level) {
+		msgs = append(msgs, p.String())
+	}
+	return strings.Join(msgs, "\n")
+}
@@ -0,0 +1,51 @@
+// Under the Apache-2.0 License
+package stexec
+
+import (
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/comments"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/testexec"
+)
+
+// Add adds in the test executions from the data-exchange format into the simplified form.
+//
+// Returns the sources whose ref is not in the document's common source refs.
+func (e *Executions) Add(src *testexec.TestExecutionV1SchemaJson) []sources.MissingRef {
+	return e.AddFile(src, nil)
+}
+
+// AddFile adds in the test executions read from the input file.
+func (e *Executions) AddFile(src *testexec.TestExecutionV1SchemaJson, input *sources.InputFile) []sources.MissingRef {
+	if src == nil || e == nil {
+		return nil
+	}
+	prep := e.sources.PrepareTestExec(&src.CommonSourceRefs, input)
+	for i, x := range src.Executions {
+		e.addExecution(&x, prep.Index("executions", i))
+	}
+	return prep.MissingRefs()
+}
+
+func (e *Executions) addExecution(x *testexec.TestExecution, prep *sources.TestExecSource) {
+	s := prep.DocumentSources(x.Sources)
+	if x.Duration != nil && *x.Duration < 0 {
+		e.Problems.AddProblemAt(prep.Origin(), s, problem.Err, "test execution %s: duration must not be negative (%f)", x.Id, *x.Duration)
+	}
+	if x.Attempts < 1 {
+		e.Problems.AddProblemAt(prep.Origin(), s, problem.Err, "test execution %s: attempts must be at least 1 (%d)", x.Id, x.Attempts)
+	}
+	e.Executions = append(e.Executions, &Execution{
+		Id:          string(x.Id),
+		Timestamp:   x.Timestamp,
+		Duration:    x.Duration,
+		Attempts:    x.Attempts,
+		Result:      string(x.Result),
+		Executed:    prep.DocumentSources(testexec.DocumentSources(x.Executed)),
+		Descriptors: descriptor.JoinTestExecDescriptors(x.Descriptors),
+		Comments:    comments.JoinTestExecComments(x),
+		Sources:     s,
+		Origin:      prep.Origin(),
+	})
+}
@@ -0,0 +1,96 @@
+// Under the Apache-2.0 License
+package stexec_test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/stexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/testexec"
+)
+
+//go:embed "exec-sample.json"
+var sample1 []byte
+
+func Test_Add(t *testing.T) {
+	var src testexec.TestExecutionV1SchemaJson
+	if err := json.Unmarshal(sample1, &src); err != nil {
+		t.Fatal(err)
+	}
+	e := stexec.New()
+	e.Add(&src)
+	if e.Problems.HasProblems() {
+		t.Fatalf("Loading the executions encountered problems: %v", e.Problems.Problems())
+	}
+	if len(e.Executions) != 2 {
+		t.Fatalf("expected 2 executions, found %v", e.Executions)
+	}
+
+	x := e.Executions[0]
+	if x.Id != "login-ok" || x.Result != "pass" || x.Attempts != 2 {
+		t.Errorf("bad execution values: %v", x)
+	}
+	if len(x.Sources) != 1 || *x.Sources[0].Ver() != "abc123" {
+		t.Errorf("expected versioned test source, found %v", x.Sources)
+	}
+	if _, offset := x.Timestamp.Zone(); offset != -7*60*60 {
+		t.Errorf("expected the timestamp time zone to remain, found %v", x.Timestamp)
+	}
+	if e.Executions[1].Attempts != 1 || e.Executions[1].Duration != nil {
+		t.Errorf("expected default attempts and no duration, found %v", e.Executions[1])
+	}
+
+	docs := e.AsDocuments()
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, found %v", docs)
+	}
+	if len(docs[0].Sources) != 1 {
+		t.Errorf("expected the document to keep the execution source, found %v", docs[0].Sources)
+	}
+	values := make(map[string][]any)
+	for _, d := range docs[0].Descriptors {
+		vals := make([]any, 0)
+		for _, v := range d.Text {
+			vals = append(vals, v)
+		}
+		for _, v := range d.Number {
+			vals = append(vals, v)
+		}
+		values[d.Key] = vals
+	}
+	expected := map[string][]any{
+		"requirement":           {"r1"},
+		stexec.TestIdKey:        {"login-ok"},
+		stexec.TestResultKey:    {"pass"},
+		stexec.TestAttemptsKey:  {2.0},
+		stexec.TestDurationKey:  {1.5},
+		stexec.TestTimestampKey: {"2024-05-01T10:15:00-07:00"},
+		stexec.TestExecutedKey:  {"src/login.go#12", "src/login.go"},
+	}
+	if diff := cmp.Diff(expected, values); diff != "" {
+		t.Errorf("descriptor mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_Ontology(t *testing.T) {
+	ont := sont.New()
+	ont.Add(stexec.Ontology())
+	if ont.Problems.HasProblems() {
+		t.Fatalf("Loading the ontology encountered problems: %v", ont.Problems.Problems())
+	}
+	for _, k := range []string{
+		stexec.TestIdKey,
+		stexec.TestResultKey,
+		stexec.TestDurationKey,
+		stexec.TestAttemptsKey,
+		stexec.TestTimestampKey,
+		stexec.TestExecutedKey,
+	} {
+		if ont.Find(k) == nil {
+			t.Errorf("missing ontology descriptor %s", k)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+// Under the Apache-2.0 License
+
+// Simplified Test Execution Collection
+//
+// This allows for unifying multiple test execution result files into a single
+// collection, and for presenting each execution as a document object with
+// synthetic descriptors, so that rules can match against the test results.
+package stexec
@@ -0,0 +1,51 @@
+// Under the Apache-2.0 License
+package stexec
+
+import (
+	"time"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// Synthetic descriptor keys added to each test execution object.
+//
+// The '$' prefix keeps them apart from the project's own descriptor keys.
+const (
+	TestIdKey        = "$test-id"
+	TestResultKey    = "$test-result"
+	TestDurationKey  = "$test-duration"
+	TestAttemptsKey  = "$test-attempts"
+	TestTimestampKey = "$test-timestamp"
+	TestExecutedKey  = "$test-executed"
+)
+
+// Executions contains all the loaded test execution results.
+type Executions struct {
+	Executions []*Execution
+	Problems   *problem.ProblemSet
+	sources    *sources.SourceGen
+}
+
+// Execution is a localized version of the test execution schema.
+type Execution struct {
+	Id          string
+	Timestamp   time.Time
+	Duration    *float64
+	Attempts    int
+	Result      string
+	Executed    []sources.Source
+	Descriptors []*descriptor.Descriptor
+	Comments    []string
+	Sources     []sources.Source
+	Origin      sources.Origin
+}
+
+func New() *Executions {
+	return &Executions{
+		Executions: make([]*Execution, 0),
+		Problems:   problem.New(),
+		sources:    sources.SourceGenerator(),
+	}
+}
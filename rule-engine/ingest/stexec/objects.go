@@ -0,0 +1,75 @@
+// Under the Apache-2.0 License
+package stexec
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"time"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/document"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/ontology"
+)
+
+//go:embed "ontology.json"
+var ontologySrc []byte
+
+// Ontology returns the ontology for the synthetic test execution descriptors.
+func Ontology() *ontology.OntologyV1SchemaJson {
+	var ret ontology.OntologyV1SchemaJson
+	dec := json.NewDecoder(bytes.NewReader(ontologySrc))
+	if err := dec.Decode(&ret); err != nil {
+		// The embedded file is part of the build, so this is a programming error.
+		panic(err)
+	}
+	return &ret
+}
+
+// AsDocuments turns each execution into a document object.
+//
+// The document object contains the execution's own descriptors, plus the
+// synthetic test descriptors.  It keeps the execution's sources.
+func (e *Executions) AsDocuments() []*sdoc.DocumentObject {
+	if e == nil {
+		return nil
+	}
+	ret := make([]*sdoc.DocumentObject, len(e.Executions))
+	for i, x := range e.Executions {
+		ret[i] = x.AsDocument()
+	}
+	return ret
+}
+
+// AsDocument turns the execution into a document object.
+func (x *Execution) AsDocument() *sdoc.DocumentObject {
+	descs := make([]*descriptor.Descriptor, 0, len(x.Descriptors)+6)
+	descs = append(descs, x.Descriptors...)
+	descs = append(descs,
+		descriptor.JoinKeyValues(TestIdKey, []string{x.Id}, nil),
+		descriptor.JoinKeyValues(TestResultKey, []string{x.Result}, nil),
+		descriptor.JoinKeyValues(TestAttemptsKey, nil, []float64{float64(x.Attempts)}),
+		descriptor.JoinKeyValues(TestTimestampKey, []string{x.Timestamp.Format(time.RFC3339)}, nil),
+	)
+	if x.Duration != nil {
+		descs = append(descs, descriptor.JoinKeyValues(TestDurationKey, nil, []float64{*x.Duration}))
+	}
+	if len(x.Executed) > 0 {
+		locs := make([]string, len(x.Executed))
+		for i, s := range x.Executed {
+			locs[i] = s.Loc()
+			if a := s.A(); a != nil {
+				locs[i] += "#" + *a
+			}
+		}
+		descs = append(descs, descriptor.JoinKeyValues(TestExecutedKey, locs, nil))
+	}
+	return &sdoc.DocumentObject{
+		Comments:    x.Comments,
+		Descriptors: descs,
+		Id:          document.Id(x.Id),
+		Sources:     x.Sources,
+		Origin:      x.Origin,
+	}
+}
@@ -0,0 +1,180 @@
+// Under the Apache-2.0 License
+package sxform
+
+import (
+	"regexp"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/comments"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/transform"
+)
+
+// Add adds in the transforms from the data-exchange format into the simplified form.
+//
+// Transforms apply in the order added.  Returns the sources whose ref is not in the
+// document's common source refs.
+func (t *Transforms) Add(obj *transform.OntologyTransformV1SchemaJson) []sources.MissingRef {
+	return t.AddFile(obj, nil)
+}
+
+// AddFile adds in the transforms read from the input file.
+func (t *Transforms) AddFile(obj *transform.OntologyTransformV1SchemaJson, input *sources.InputFile) []sources.MissingRef {
+	if obj == nil || t == nil {
+		return nil
+	}
+	prep := t.sources.PrepareTransform(&obj.CommonSourceRefs, input)
+	for i, x := range obj.Transforms {
+		xPrep := prep.Index("transforms", i)
+		s := xPrep.DocumentSources(x.Sources)
+		t.Transforms = append(t.Transforms, &Transform{
+			From:     joinInput(&x.From, xPrep.Field("from"), t.Problems),
+			To:       joinOutputs(x.To, xPrep, t.Problems),
+			Comments: comments.JoinTransformComments(x.Comment, x.Comments),
+			Sources:  s,
+			Origin:   xPrep.Origin(),
+		})
+	}
+	return prep.MissingRefs()
+}
+
+func joinInput(
+	in *transform.TransformInput,
+	src *sources.TransformSource,
+	probs *problem.ProblemSet,
+) *Input {
+	s := src.DocumentSources(in.Sources)
+	ret := &Input{
+		Operation: toInputOperation(in.MatchType, s, probs),
+		Sub:       make([]*Input, 0, len(in.Sub)),
+		Values:    make([]*regexp.Regexp, 0, len(in.Values)+len(in.ValuePatterns)),
+		Sources:   s,
+	}
+	for i, sub := range in.Sub {
+		if sub != nil {
+			ret.Sub = append(ret.Sub, joinInput(sub, src.Index("sub", i), probs))
+		}
+	}
+
+	switch ret.Operation {
+	case AndInput, OrInput:
+		if len(ret.Sub) <= 0 {
+			probs.AddError(s, "transform input '%s' requires at least one sub input", in.MatchType)
+		}
+		return ret
+	case NotInput:
+		if len(ret.Sub) != 1 {
+			probs.AddError(s, "transform input 'not' requires exactly one sub input (%d)", len(ret.Sub))
+		}
+		return ret
+	}
+
+	switch {
+	case in.Descriptor != nil && in.DescriptorPattern != nil:
+		probs.AddError(s, "transform input '%s' must have only one of descriptor or descriptorPattern", in.MatchType)
+	case in.Descriptor != nil:
+		ret.Key = compile("^"+regexp.QuoteMeta(*in.Descriptor)+"$", s, probs)
+	case in.DescriptorPattern != nil:
+		ret.Key = compile(*in.DescriptorPattern, s, probs)
+	default:
+		probs.AddError(s, "transform input '%s' requires a descriptor or descriptorPattern", in.MatchType)
+	}
+
+	for _, v := range in.Values {
+		if re := compile("^"+regexp.QuoteMeta(v)+"$", s, probs); re != nil {
+			ret.Values = append(ret.Values, re)
+		}
+	}
+	for _, v := range in.ValuePatterns {
+		if re := compile(v, s, probs); re != nil {
+			ret.Values = append(ret.Values, re)
+		}
+	}
+	if ret.Operation != DescriptorInput && len(in.Values)+len(in.ValuePatterns) <= 0 {
+		probs.AddError(s, "transform input '%s' requires values or valuePatterns", in.MatchType)
+	}
+	return ret
+}
+
+func compile(pattern string, s []sources.Source, probs *problem.ProblemSet) *regexp.Regexp {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		probs.AddError(s, "invalid transform pattern '%s': %s", pattern, err.Error())
+		return nil
+	}
+	return re
+}
+
+func joinOutputs(
+	outs []transform.TransformOutput,
+	src *sources.TransformSource,
+	probs *problem.ProblemSet,
+) []Output {
+	ret := make([]Output, 0, len(outs))
+	for i, o := range outs {
+		s := src.Index("to", i).DocumentSources(o.Sources)
+		texts, numbers := descriptor.Join(descriptor.DecodeTransformValues(o.Values))
+		ret = append(ret, Output{
+			Key:          string(o.Descriptor),
+			Action:       toOutputAction(o.Action, s, probs),
+			TextValues:   texts,
+			NumberValues: numbers,
+			Comments:     comments.JoinTransformComments(o.Comment, o.Comments),
+			Sources:      s,
+		})
+	}
+	return ret
+}
+
+func toInputOperation(
+	m transform.TransformInputMatchType,
+	s []sources.Source,
+	p *problem.ProblemSet,
+) InputOperation {
+	switch m {
+	case transform.TransformInputMatchTypeAnd:
+		return AndInput
+	case transform.TransformInputMatchTypeOr:
+		return OrInput
+	case transform.TransformInputMatchTypeNot:
+		return NotInput
+	case transform.TransformInputMatchTypeDescriptor:
+		return DescriptorInput
+	case transform.TransformInputMatchTypeOneOf:
+		return OneOfInput
+	case transform.TransformInputMatchTypeAllOf:
+		return AllOfInput
+	case transform.TransformInputMatchTypeIncludes:
+		return IncludesInput
+	case transform.TransformInputMatchTypeEquals:
+		return EqualsInput
+	}
+	p.AddError(
+		s,
+		"unsupported transform match type (%s)",
+		m,
+	)
+	return AndInput
+}
+
+func toOutputAction(
+	a transform.TransformOutputAction,
+	s []sources.Source,
+	p *problem.ProblemSet,
+) OutputAction {
+	switch a {
+	case transform.TransformOutputActionAdd:
+		return AddOutput
+	case transform.TransformOutputActionSet:
+		return SetOutput
+	case transform.TransformOutputActionRemove:
+		return RemoveOutput
+	}
+	p.AddError(
+		s,
+		"unsupported transform output action (%s)",
+		a,
+	)
+	return AddOutput
+}
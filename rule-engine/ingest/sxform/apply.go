@@ -0,0 +1,216 @@
+// Under the Apache-2.0 License
+package sxform
+
+import (
+	"strconv"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+)
+
+// Apply runs every transform, in order, over the document objects, and returns the transformed objects.
+//
+// Each transform matches against the descriptors as altered by the earlier
+// transforms.  The returned objects keep the identifier, comments, and sources
+// of the original objects, and the original objects remain unchanged.
+func (t *Transforms) Apply(objs []*sdoc.DocumentObject) []*sdoc.DocumentObject {
+	if t == nil || len(t.Transforms) <= 0 {
+		return objs
+	}
+	ret := make([]*sdoc.DocumentObject, len(objs))
+	for i, o := range objs {
+		ret[i] = t.applyObject(o)
+	}
+	return ret
+}
+
+func (t *Transforms) applyObject(o *sdoc.DocumentObject) *sdoc.DocumentObject {
+	if o == nil {
+		return nil
+	}
+	descs := make([]*descriptor.Descriptor, 0, len(o.Descriptors))
+	for _, d := range o.Descriptors {
+		if d != nil {
+			descs = append(descs, copyDescriptor(d))
+		}
+	}
+	for _, x := range t.Transforms {
+		if x.From.Matches(descs) {
+			for _, out := range x.To {
+				descs = out.apply(descs)
+			}
+		}
+	}
+	return &sdoc.DocumentObject{
+		Comments:    o.Comments,
+		Descriptors: descs,
+		Id:          o.Id,
+		Sources:     o.Sources,
+		Origin:      o.Origin,
+	}
+}
+
+// Matches checks whether the descriptors match the input.
+func (in *Input) Matches(descs []*descriptor.Descriptor) bool {
+	if in == nil {
+		return false
+	}
+	switch in.Operation {
+	case AndInput:
+		for _, s := range in.Sub {
+			if !s.Matches(descs) {
+				return false
+			}
+		}
+		return len(in.Sub) > 0
+	case OrInput:
+		for _, s := range in.Sub {
+			if s.Matches(descs) {
+				return true
+			}
+		}
+		return false
+	case NotInput:
+		return len(in.Sub) == 1 && !in.Sub[0].Matches(descs)
+	}
+
+	values := in.keyValues(descs)
+	switch in.Operation {
+	case DescriptorInput:
+		return len(values) > 0
+	case OneOfInput:
+		return in.anyValueListed(values)
+	case AllOfInput:
+		return in.allValuesListed(values)
+	case IncludesInput:
+		return in.allListedIncluded(values)
+	case EqualsInput:
+		return in.allValuesListed(values) && in.allListedIncluded(values)
+	}
+	return false
+}
+
+// keyValues returns the text form of all the values for the descriptors matching the input key.
+func (in *Input) keyValues(descs []*descriptor.Descriptor) []string {
+	ret := make([]string, 0)
+	if in.Key == nil {
+		return ret
+	}
+	for _, d := range descs {
+		if !in.Key.MatchString(d.Key) {
+			continue
+		}
+		ret = append(ret, d.Text...)
+		for _, n := range d.Number {
+			ret = append(ret, strconv.FormatFloat(n, 'f', -1, 64))
+		}
+	}
+	return ret
+}
+
+func (in *Input) listed(v string) bool {
+	for _, re := range in.Values {
+		if re.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *Input) anyValueListed(values []string) bool {
+	for _, v := range values {
+		if in.listed(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *Input) allValuesListed(values []string) bool {
+	if len(values) <= 0 {
+		return false
+	}
+	for _, v := range values {
+		if !in.listed(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (in *Input) allListedIncluded(values []string) bool {
+	if len(in.Values) <= 0 {
+		return false
+	}
+	for _, re := range in.Values {
+		found := false
+		for _, v := range values {
+			if re.MatchString(v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// apply performs the output action on the descriptors, returning the updated descriptor list.
+func (out *Output) apply(descs []*descriptor.Descriptor) []*descriptor.Descriptor {
+	var target *descriptor.Descriptor
+	index := -1
+	for i, d := range descs {
+		if d.Key == out.Key {
+			target = d
+			index = i
+			break
+		}
+	}
+
+	switch out.Action {
+	case AddOutput, SetOutput:
+		if target == nil {
+			target = descriptor.JoinKeyValues(out.Key, make([]string, 0), make([]float64, 0))
+			descs = append(descs, target)
+		} else if out.Action == SetOutput {
+			target.Text = make([]string, 0, len(out.TextValues))
+			target.Number = make([]float64, 0, len(out.NumberValues))
+		}
+		target.Text = append(target.Text, out.TextValues...)
+		target.Number = append(target.Number, out.NumberValues...)
+	case RemoveOutput:
+		if target == nil {
+			return descs
+		}
+		if len(out.TextValues)+len(out.NumberValues) <= 0 {
+			return append(descs[:index], descs[index+1:]...)
+		}
+		target.Text = removeValues(target.Text, out.TextValues)
+		target.Number = removeValues(target.Number, out.NumberValues)
+	}
+	return descs
+}
+
+func removeValues[T descriptor.DescriptorValueTypes](base []T, remove []T) []T {
+	drop := make(map[T]bool)
+	for _, v := range remove {
+		drop[v] = true
+	}
+	ret := make([]T, 0, len(base))
+	for _, v := range base {
+		if !drop[v] {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func copyDescriptor(d *descriptor.Descriptor) *descriptor.Descriptor {
+	return descriptor.JoinKeyValues(
+		d.Key,
+		descriptor.DescriptorValueCopy(d.Text, nil),
+		descriptor.DescriptorValueCopy(d.Number, nil),
+	)
+}
@@ -0,0 +1,132 @@
+// Under the Apache-2.0 License
+package sxform_test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sxform"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/transform"
+)
+
+//go:embed "xform-sample.json"
+var sample1 []byte
+
+func Test_Apply(t *testing.T) {
+	var src transform.OntologyTransformV1SchemaJson
+	if err := json.Unmarshal(sample1, &src); err != nil {
+		t.Fatal(err)
+	}
+	x := sxform.New()
+	x.Add(&src)
+	if x.Problems.HasProblems() {
+		t.Fatalf("Loading the transforms encountered problems: %v", x.Problems.Problems())
+	}
+	if len(x.Transforms) != 3 {
+		t.Fatalf("expected 3 transforms, found %v", x.Transforms)
+	}
+
+	orig := []*sdoc.DocumentObject{
+		{Id: "o1", Descriptors: []*descriptor.Descriptor{
+			descriptor.JoinKeyValues("label", []string{"req-a", "other"}, nil),
+			descriptor.JoinKeyValues("priority", []string{"high"}, nil),
+			descriptor.JoinKeyValues("team", []string{"qa"}, nil),
+			descriptor.JoinKeyValues("size", nil, []float64{3, 4}),
+		}},
+		{Id: "o2", Descriptors: []*descriptor.Descriptor{
+			descriptor.JoinKeyValues("label", []string{"x"}, nil),
+			descriptor.JoinKeyValues("priority", []string{"low"}, nil),
+			descriptor.JoinKeyValues("team", []string{"qa", "dev"}, nil),
+		}},
+	}
+	res := x.Apply(orig)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 objects, found %v", res)
+	}
+
+	expected := map[string]map[string][]string{
+		"o1": {
+			"priority":    {"high"},
+			"team":        {"qa"},
+			"size":        {"4"},
+			"requirement": {"A"},
+		},
+		"o2": {
+			"label":    {"x"},
+			"priority": {"low"},
+			"team":     {"qa", "dev"},
+			"owner":    {"dev"},
+		},
+	}
+	for i, o := range res {
+		if o.Id != orig[i].Id {
+			t.Errorf("expected id %s, found %s", orig[i].Id, o.Id)
+		}
+		if diff := cmp.Diff(expected[string(o.Id)], asMap(o.Descriptors)); diff != "" {
+			t.Errorf("object %s descriptor mismatch (-want +got):\n%s", o.Id, diff)
+		}
+	}
+	if len(orig[0].Descriptors) != 4 || len(orig[0].Descriptors[0].Text) != 2 {
+		t.Errorf("original object altered: %v", orig[0].Descriptors)
+	}
+}
+
+func Test_Add_Invalid(t *testing.T) {
+	var src transform.OntologyTransformV1SchemaJson
+	if err := json.Unmarshal([]byte(`{
+		"$schema": "https://qazaar.groboclown.net/schema/ontology-transform.v1.schema.json",
+		"transforms": [
+			{"from": {"matchType": "not", "sub": []}, "to": []},
+			{"from": {"matchType": "one-of", "descriptor": "a"}, "to": []},
+			{"from": {"matchType": "all-of", "values": ["a"]}, "to": []},
+			{"from": {"matchType": "includes", "descriptor": "a", "valuePatterns": ["("]}, "to": []}
+		]
+	}`), &src); err != nil {
+		t.Fatal(err)
+	}
+	x := sxform.New()
+	x.Add(&src)
+	if len(x.Problems.Problems()) != 4 {
+		t.Errorf("expected 4 problems, found %v", x.Problems.Problems())
+	}
+}
+
+func Test_Add_MissingRefPath(t *testing.T) {
+	var src transform.OntologyTransformV1SchemaJson
+	if err := json.Unmarshal([]byte(`{
+		"$schema": "https://qazaar.groboclown.net/schema/ontology-transform.v1.schema.json",
+		"commonSourceRefs": [{"id": "known", "rep": "git", "loc": "a.json"}],
+		"transforms": [
+			{"from": {"matchType": "or", "sources": [{"ref": "known"}], "sub": [
+				{"matchType": "descriptor", "descriptor": "a"},
+				{"matchType": "descriptor", "descriptor": "b", "sources": [{"ref": "unknown"}]}
+			]}, "to": []}
+		]
+	}`), &src); err != nil {
+		t.Fatal(err)
+	}
+	x := sxform.New()
+	missing := x.Add(&src)
+	expected := []sources.MissingRef{{Ref: "unknown", Path: "/transforms/0/from/sub/1"}}
+	if diff := cmp.Diff(expected, missing); diff != "" {
+		t.Errorf("missing ref mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func asMap(descs []*descriptor.Descriptor) map[string][]string {
+	ret := make(map[string][]string)
+	for _, d := range descs {
+		vals := make([]string, 0)
+		vals = append(vals, d.Text...)
+		for _, n := range d.Number {
+			vals = append(vals, descriptor.DescriptorValue{Number: &n}.String())
+		}
+		ret[d.Key] = vals
+	}
+	return ret
+}
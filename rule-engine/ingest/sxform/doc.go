@@ -0,0 +1,8 @@
+// Under the Apache-2.0 License
+
+// Simplified Ontology Transform Collection
+//
+// This allows for unifying multiple ontology transform files into a single, ordered
+// collection, and applying those transforms to the document objects before any
+// validation or rule processing.
+package sxform
@@ -0,0 +1,75 @@
+// Under the Apache-2.0 License
+package sxform
+
+import (
+	"regexp"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// Transforms contains the ordered collection of all processed ontology transforms.
+type Transforms struct {
+	Transforms []*Transform
+	Problems   *problem.ProblemSet
+	sources    *sources.SourceGen
+}
+
+type Transform struct {
+	From     *Input
+	To       []Output
+	Comments []string
+	Sources  []sources.Source
+	Origin   sources.Origin
+}
+
+type InputOperation int
+
+const (
+	AndInput InputOperation = iota
+	OrInput
+	NotInput
+	DescriptorInput
+	OneOfInput
+	AllOfInput
+	IncludesInput
+	EqualsInput
+)
+
+// Input matches against the descriptors of a document object.
+//
+// The 'and', 'or', and 'not' operations use the Sub inputs; the others use the
+// Key to find the descriptors and the Values to compare against the descriptor
+// values.
+type Input struct {
+	Operation InputOperation
+	Sub       []*Input
+	Key       *regexp.Regexp
+	Values    []*regexp.Regexp
+	Sources   []sources.Source
+}
+
+type OutputAction int
+
+const (
+	AddOutput OutputAction = iota
+	SetOutput
+	RemoveOutput
+)
+
+type Output struct {
+	Key          string
+	Action       OutputAction
+	TextValues   []string
+	NumberValues []float64
+	Comments     []string
+	Sources      []sources.Source
+}
+
+func New() *Transforms {
+	return &Transforms{
+		Transforms: make([]*Transform, 0),
+		Problems:   problem.New(),
+		sources:    sources.SourceGenerator(),
+	}
+}
@@ -0,0 +1,49 @@
+// Under the Apache-2.0 License
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/stexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/testexec"
+)
+
+// ReadTestExecutions adds all the test execution files listed in the project configuration.
+func ReadTestExecutions(d *stexec.Executions, files []string) error {
+	errs := make([]error, 0)
+	for _, f := range files {
+		in, err := readInputFile(f, ParseTestExecution)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		d.AddFile(in.data, in.input)
+	}
+	return errors.Join(errs...)
+}
+
+func ReadTestExecutionFile(f string) (*testexec.TestExecutionV1SchemaJson, error) {
+	r, err := os.Open(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ParseTestExecution(r, f)
+}
+
+func ParseTestExecution(r io.Reader, src string) (*testexec.TestExecutionV1SchemaJson, error) {
+	var ret testexec.TestExecutionV1SchemaJson
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", src, err.Error())
+	}
+	err = json.Unmarshal(data, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", src, err.Error())
+	}
+	return &ret, nil
+}
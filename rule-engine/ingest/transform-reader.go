@@ -0,0 +1,49 @@
+// Under the Apache-2.0 License
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sxform"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/transform"
+)
+
+// ReadTransform adds all the ontology transform files listed in the project configuration.
+func ReadTransform(d *sxform.Transforms, files []string) error {
+	errs := make([]error, 0)
+	for _, f := range files {
+		in, err := readInputFile(f, ParseTransform)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		d.AddFile(in.data, in.input)
+	}
+	return errors.Join(errs...)
+}
+
+func ReadTransformFile(f string) (*transform.OntologyTransformV1SchemaJson, error) {
+	r, err := os.Open(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ParseTransform(r, f)
+}
+
+func ParseTransform(r io.Reader, src string) (*transform.OntologyTransformV1SchemaJson, error) {
+	var ret transform.OntologyTransformV1SchemaJson
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", src, err.Error())
+	}
+	err = json.Unmarshal(data, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", src, err.Error())
+	}
+	return &ret, nil
+}
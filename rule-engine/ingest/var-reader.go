@@ -0,0 +1,82 @@
+// Under the Apache-2.0 License
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/config"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+)
+
+// LoadVariables reads the variable files listed in the project configuration, then adds the configuration's variables.
+//
+// Later files override earlier files, and the configuration values override all files.
+func LoadVariables(c *config.ProjectConfig) (srule.VariableValues, error) {
+	ret := make(srule.VariableValues)
+	if c == nil {
+		return ret, nil
+	}
+	files, err := FindFiles(c.RefDirs, c.VariableFiles)
+	if err != nil {
+		return ret, err
+	}
+	errs := []error{ReadVariables(ret, files)}
+	errs = append(errs, addVariables(ret, c.Variables, "config"))
+	return ret, errors.Join(errs...)
+}
+
+// ReadVariables adds all the variables in the files to the variable values.
+func ReadVariables(d srule.VariableValues, files []string) error {
+	errs := make([]error, 0)
+	for _, f := range files {
+		src, err := ReadVariablesFile(f)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		errs = append(errs, addVariables(d, src, f))
+	}
+	return errors.Join(errs...)
+}
+
+func ReadVariablesFile(f string) (map[string]any, error) {
+	r, err := os.Open(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ParseVariables(r, f)
+}
+
+// ParseVariables reads a JSON object, mapping each variable name to its text or number value.
+func ParseVariables(r io.Reader, src string) (map[string]any, error) {
+	var ret map[string]any
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", src, err.Error())
+	}
+	err = json.Unmarshal(data, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", src, err.Error())
+	}
+	return ret, nil
+}
+
+func addVariables(d srule.VariableValues, vals map[string]any, src string) error {
+	errs := make([]error, 0)
+	for k, v := range vals {
+		switch t := v.(type) {
+		case string:
+			d[k] = t
+		case float64:
+			d[k] = strconv.FormatFloat(t, 'f', -1, 64)
+		default:
+			errs = append(errs, fmt.Errorf("%s: variable '%s' must be a text or number value (%v)", src, k, v))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -35,10 +35,11 @@ func Test_Reader(t *testing.T) {
 	if len(all.OntDescriptors.Enums()) != 3 {
 		t.Errorf("incorrectly read ont-enum (%d)", len(all.OntDescriptors.Enums()))
 	}
-	if len(all.OntDescriptors.Numerics()) != 0 {
+	// The groups add the member meta-descriptors.
+	if len(all.OntDescriptors.Numerics()) != 1 {
 		t.Errorf("incorrectly read ont-numeric (%d)", len(all.OntDescriptors.Numerics()))
 	}
-	if len(all.OntDescriptors.Frees()) != 4 {
+	if len(all.OntDescriptors.Frees()) != 5 {
 		t.Errorf("incorrectly read ont-free (%d)", len(all.OntDescriptors.Frees()))
 	}
 	if len(all.RuleSets.Groups) != 2 {
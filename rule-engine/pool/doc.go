@@ -0,0 +1,5 @@
+// Under the Apache-2.0 License
+//
+// A bounded worker pool, shared by the validation and the engine so large
+// inputs don't create a goroutine for every piece of work.
+package pool
@@ -0,0 +1,80 @@
+// Under the Apache-2.0 License
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Pool bounds the number of goroutines running tasks.
+//
+// When every worker is busy, the submitting goroutine runs the task itself.
+// That throttles the producers, and keeps tasks that submit and wait on more
+// tasks from deadlocking.
+type Pool struct {
+	ctx   context.Context
+	slots chan struct{}
+}
+
+type poolKey struct{}
+
+// New creates a pool with the parallelism number of workers, or one per CPU if it isn't positive.
+//
+// Once the context is cancelled, the pool drops the submitted tasks.
+func New(ctx context.Context, parallelism int) *Pool {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	return &Pool{ctx: ctx, slots: make(chan struct{}, parallelism)}
+}
+
+// With returns a context carrying the pool, for the validation and engine calls.
+func With(ctx context.Context, p *Pool) context.Context {
+	return context.WithValue(ctx, poolKey{}, p)
+}
+
+// From returns the pool carried by the context, or a new default pool for the context.
+func From(ctx context.Context) *Pool {
+	if p, ok := ctx.Value(poolKey{}).(*Pool); ok && p != nil {
+		return p
+	}
+	return New(ctx, 0)
+}
+
+// Go runs the task on an idle worker, or in the caller if all are busy, and marks the wait group done once it completes.
+//
+// Returns false without running the task if the context was cancelled.
+func (p *Pool) Go(wg *sync.WaitGroup, task func()) bool {
+	if p.ctx.Err() != nil {
+		return false
+	}
+	wg.Add(1)
+	select {
+	case p.slots <- struct{}{}:
+		go func() {
+			defer func() {
+				<-p.slots
+				wg.Done()
+			}()
+			task()
+		}()
+	default:
+		defer wg.Done()
+		task()
+	}
+	return true
+}
+
+// WithCancel returns a pool sharing the workers, which drops its tasks once cancelled.
+//
+// The engine cancels its pool when it halts, so the outstanding work never runs.
+func (p *Pool) WithCancel() (*Pool, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(p.ctx)
+	return &Pool{ctx: ctx, slots: p.slots}, cancel
+}
+
+// Err returns the context error once the pool stops accepting tasks.
+func (p *Pool) Err() error {
+	return p.ctx.Err()
+}
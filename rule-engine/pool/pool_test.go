@@ -0,0 +1,107 @@
+// Under the Apache-2.0 License
+package pool_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
+)
+
+func Test_Pool_Bounded(t *testing.T) {
+	p := pool.New(context.Background(), 2)
+	var wg, started sync.WaitGroup
+	block := make(chan struct{})
+	started.Add(2)
+	for i := 0; i < 2; i++ {
+		p.Go(&wg, func() {
+			started.Done()
+			<-block
+		})
+	}
+	started.Wait()
+
+	// Both workers are busy, so the caller runs the task before Go returns.
+	inline := false
+	p.Go(&wg, func() { inline = true })
+	if !inline {
+		t.Error("expected the task to run in the caller")
+	}
+	close(block)
+	wg.Wait()
+}
+
+func Test_Pool_Nested(t *testing.T) {
+	p := pool.New(context.Background(), 1)
+	var wg sync.WaitGroup
+	var ran atomic.Int32
+	var task func(depth int)
+	task = func(depth int) {
+		ran.Add(1)
+		if depth > 0 {
+			// Waiting on nested tasks must not deadlock when the workers are busy.
+			var inner sync.WaitGroup
+			for i := 0; i < 3; i++ {
+				p.Go(&inner, func() { task(depth - 1) })
+			}
+			inner.Wait()
+		}
+	}
+	for i := 0; i < 3; i++ {
+		p.Go(&wg, func() { task(2) })
+	}
+	wg.Wait()
+	if ran.Load() != 3*(1+3+9) {
+		t.Errorf("expected every task to run, found %d", ran.Load())
+	}
+}
+
+func Test_Pool_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := pool.New(ctx, 1)
+	var wg sync.WaitGroup
+	ran := 0
+	if !p.Go(&wg, func() { ran++ }) {
+		t.Error("expected the task to run before cancelling")
+	}
+	wg.Wait()
+	cancel()
+	if p.Go(&wg, func() { ran++ }) {
+		t.Error("expected the cancelled pool to drop the task")
+	}
+	wg.Wait()
+	if ran != 1 || p.Err() == nil {
+		t.Errorf("expected only the first task to run, found %d", ran)
+	}
+}
+
+func Test_From(t *testing.T) {
+	ctx := context.Background()
+	p := pool.New(ctx, 1)
+	if pool.From(pool.With(ctx, p)) != p {
+		t.Error("expected the context's pool")
+	}
+	if pool.From(ctx) == nil {
+		t.Error("expected a default pool")
+	}
+}
+
+func Test_Pool_WithCancel(t *testing.T) {
+	p := pool.New(context.Background(), 1)
+	child, cancel := p.WithCancel()
+	cancel()
+	var wg sync.WaitGroup
+	if child.Go(&wg, func() { t.Error("cancelled pool ran the task") }) {
+		t.Error("expected the cancelled pool to drop the task")
+	}
+	ran := false
+	if !p.Go(&wg, func() { ran = true }) {
+		t.Error("expected the parent pool to accept the task")
+	}
+	wg.Wait()
+	if !ran {
+		t.Error("expected the parent pool to run the task")
+	}
+}
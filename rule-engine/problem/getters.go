@@ -5,6 +5,29 @@ func (p Problem) String() string {
 	return p.Message
 }
 
+// Subject returns the problem's subject, or nil if the problem's context does not have one.
+func (p Problem) Subject() *Subject {
+	if c, ok := p.Context.(SubjectContext); ok {
+		s := c.Subject()
+		return &s
+	}
+	return nil
+}
+
+func (l ProblemLevel) String() string {
+	switch l {
+	case Quiet:
+		return "quiet"
+	case Info:
+		return "info"
+	case Warn:
+		return "warning"
+	case Err:
+		return "error"
+	}
+	return "unknown"
+}
+
 func (ps *ProblemSet) HasProblems() bool {
 	return len(ps.p) > 0
 }
@@ -67,6 +67,22 @@ func (ps *ProblemSet) AddProblem(
 	})
 }
 
+// AddProblemAt adds a problem for the ingested element read at the origin.
+func (ps *ProblemSet) AddProblemAt(
+	origin sources.Origin,
+	sources []sources.Source,
+	level ProblemLevel,
+	format string,
+	args ...any,
+) {
+	ps.Add(Problem{
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+		Sources: sources,
+		Origin:  origin,
+	})
+}
+
 func (ps *ProblemSet) Error(source string, err ...error) {
 	for _, e := range err {
 		if e != nil {
@@ -17,6 +17,7 @@ type Problem struct {
 	Level   ProblemLevel
 	Message string
 	Sources []sources.Source
+	Origin  sources.Origin // Where the ingest read the element with the problem, if known.
 	Context any
 }
 
@@ -56,3 +57,16 @@ type Adder interface {
 		args ...any,
 	)
 }
+
+// Subject describes what caused a problem generated from rule evaluation.
+type Subject struct {
+	RuleId    string   // Rule identifier, or "" if a rule did not cause the problem.
+	GroupId   string   // SOG group identifier, or "" if a group did not cause the problem.
+	SogId     string   // SOG instance identifier, or "" if the problem is not within a SOG instance.
+	ObjectIds []string // Identifiers of the objects involved in the problem.
+}
+
+// SubjectContext allows a problem's Context to describe the problem subject.
+type SubjectContext interface {
+	Subject() Subject
+}
@@ -0,0 +1,73 @@
+// Under the Apache-2.0 License
+package problem
+
+import (
+	"fmt"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+)
+
+type originAdder struct {
+	Adder
+	origin sources.Origin
+}
+
+// WithOrigin returns an adder that sets the origin on the problems without one.
+//
+// This lets the checks on an ingested element report where the element came from,
+// without passing the origin to every check.
+func WithOrigin(a Adder, origin sources.Origin) Adder {
+	if origin.IsZero() {
+		return a
+	}
+	return &originAdder{Adder: a, origin: origin}
+}
+
+func (oa *originAdder) Add(p ...Problem) {
+	for i := range p {
+		if p[i].Origin.IsZero() {
+			p[i].Origin = oa.origin
+		}
+	}
+	oa.Adder.Add(p...)
+}
+
+func (oa *originAdder) Error(source string, err ...error) {
+	for _, e := range err {
+		if e != nil {
+			oa.AddProblem(nil, Err, "%s: %s", source, e.Error())
+		}
+	}
+}
+
+func (oa *originAdder) Recover(source string, recover any) {
+	if recover != nil {
+		oa.AddProblem(nil, Err, "%s: runtime error (%v)", source, recover)
+	}
+}
+
+func (oa *originAdder) AddError(sources []sources.Source, format string, args ...any) {
+	oa.AddProblem(sources, Err, format, args...)
+}
+
+func (oa *originAdder) AddWarning(sources []sources.Source, format string, args ...any) {
+	oa.AddProblem(sources, Warn, format, args...)
+}
+
+func (oa *originAdder) AddInfo(sources []sources.Source, format string, args ...any) {
+	oa.AddProblem(sources, Info, format, args...)
+}
+
+func (oa *originAdder) AddProblem(
+	sources []sources.Source,
+	level ProblemLevel,
+	format string,
+	args ...any,
+) {
+	oa.Adder.Add(Problem{
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+		Sources: sources,
+		Origin:  oa.origin,
+	})
+}
@@ -0,0 +1,8 @@
+// Under the Apache-2.0 License
+
+// Machine-readable run reports.
+//
+// The report collects the problems from each phase of the run, along with the
+// input files, and writes them into a report directory for other tools to
+// parse.
+package report
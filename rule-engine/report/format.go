@@ -0,0 +1,49 @@
+// Under the Apache-2.0 License
+package report
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Report file formats.
+const (
+	JsonFormat  = "json"
+	SarifFormat = "sarif"
+	JunitFormat = "junit"
+)
+
+// Writer writes the report in a single format into the directory.
+type Writer func(r *Report, dir string) error
+
+// Writers maps each report format name to its writer.
+var Writers = map[string]Writer{
+	JsonFormat:  (*Report).WriteJson,
+	SarifFormat: (*Report).WriteSarif,
+	JunitFormat: (*Report).WriteJunit,
+}
+
+// FormatNames returns the sorted list of supported report formats.
+func FormatNames() []string {
+	ret := make([]string, 0, len(Writers))
+	for k := range Writers {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Write writes the report in each of the formats into the directory.
+func (r *Report) Write(dir string, formats []string) error {
+	errs := make([]error, 0)
+	for _, f := range formats {
+		w, ok := Writers[f]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown report format '%s'", f))
+			continue
+		}
+		errs = append(errs, w(r, dir))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,29 @@
+// Under the Apache-2.0 License
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// WriteJson writes the report and the summary files into the directory, creating the directory if necessary.
+func (r *Report) WriteJson(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	r.Sort()
+	return errors.Join(
+		writeJsonFile(filepath.Join(dir, ReportFile), r),
+		writeJsonFile(filepath.Join(dir, SummaryFile), r.Summary()),
+	)
+}
+
+func writeJsonFile(f string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f, data, 0o644)
+}
@@ -0,0 +1,151 @@
+// Under the Apache-2.0 License
+package report
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+const (
+	JunitFile = "qazaar-junit.xml"
+
+	// JunitRulesSuite names the suite for rules and groups without a known rule file.
+	JunitRulesSuite = "rules"
+
+	// JunitValidationSuite names the suite containing the input validation result.
+	JunitValidationSuite = "validation"
+)
+
+// JunitTestSuites is the top level JUnit XML document.
+type JunitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JunitTestSuite `xml:"testsuite"`
+}
+
+// JunitTestSuite contains the test cases for a single rule file.
+type JunitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []JunitTestCase `xml:"testcase"`
+}
+
+// JunitTestCase is a single rule or group evaluation against an object.
+type JunitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *JunitFailure `xml:"failure,omitempty"`
+}
+
+type JunitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Detail  string `xml:",chardata"`
+}
+
+// WriteJunit writes the report as a JUnit XML file into the directory, creating the directory if necessary.
+func (r *Report) WriteJunit(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := xml.MarshalIndent(r.Junit(), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	return os.WriteFile(filepath.Join(dir, JunitFile), data, 0o644)
+}
+
+// Junit converts the report into the JUnit form.
+//
+// The validation phase becomes a single test case, failing if validation found
+// any error.  Each rule file becomes a test suite, with one test case for each
+// recorded check.
+func (r *Report) Junit() *JunitTestSuites {
+	r.Sort()
+	timestamp := r.Generated.UTC().Format(time.RFC3339)
+	ret := &JunitTestSuites{Name: ToolName}
+
+	validation := JunitTestSuite{
+		Name:      JunitValidationSuite,
+		Timestamp: timestamp,
+		Cases:     []JunitTestCase{r.junitValidation()},
+	}
+	suites := []*JunitTestSuite{&validation}
+	byFile := make(map[string]*JunitTestSuite)
+	for _, c := range r.Checks {
+		name := c.File
+		if name == "" {
+			name = JunitRulesSuite
+		}
+		suite, ok := byFile[name]
+		if !ok {
+			suite = &JunitTestSuite{Name: name, Timestamp: timestamp, Cases: make([]JunitTestCase, 0)}
+			byFile[name] = suite
+			suites = append(suites, suite)
+		}
+		suite.Cases = append(suite.Cases, junitCase(c))
+	}
+
+	for _, s := range suites {
+		s.Tests = len(s.Cases)
+		for _, c := range s.Cases {
+			if c.Failure != nil {
+				s.Failures++
+			}
+		}
+		ret.Tests += s.Tests
+		ret.Failures += s.Failures
+		ret.Suites = append(ret.Suites, *s)
+	}
+	return ret
+}
+
+func (r *Report) junitValidation() JunitTestCase {
+	ret := JunitTestCase{ClassName: JunitValidationSuite, Name: "inputs"}
+	errs := make([]string, 0)
+	for _, p := range r.Problems {
+		if p.Phase == ValidationPhase && p.level == problem.Err {
+			errs = append(errs, p.Message)
+		}
+	}
+	if len(errs) > 0 {
+		ret.Failure = &JunitFailure{
+			Message: errs[0],
+			Type:    problem.Err.String(),
+			Detail:  strings.Join(errs, "\n"),
+		}
+	}
+	return ret
+}
+
+func junitCase(c runner.Check) JunitTestCase {
+	name := c.ObjectId + " " + c.Kind
+	if c.Key != "" {
+		name += " " + c.Key
+	}
+	ret := JunitTestCase{ClassName: c.RuleId, Name: name}
+	if c.RuleId == "" {
+		ret.ClassName = c.GroupId
+	}
+	if !c.Passed() {
+		ret.Failure = &JunitFailure{
+			Message: c.Failures[0],
+			Type:    c.Level.String(),
+			Detail:  strings.Join(c.Failures, "\n"),
+		}
+	}
+	return ret
+}
@@ -0,0 +1,71 @@
+// Under the Apache-2.0 License
+package report_test
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/report"
+)
+
+func Test_WriteJunit(t *testing.T) {
+	validation := problem.New()
+	validation.AddWarning(nil, "unused variable")
+
+	rep := report.New(&ingest.Manifest{}, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	rep.Add(report.ValidationPhase, validation)
+	rep.Checks = []runner.Check{
+		{RuleId: "r1", File: "a.rules.json", ObjectId: "o1", Kind: runner.ConformityCheck},
+		{RuleId: "r1", File: "a.rules.json", ObjectId: "o2", Kind: runner.ConformityCheck,
+			Level: problem.Err, Failures: []string{"Mismatch for o2: first", "Mismatch for o2: second"}},
+		{GroupId: "g1", ObjectId: "s1", Kind: runner.ConvergenceCheck, Key: "size"},
+	}
+	dir := t.TempDir()
+	if err := rep.Write(dir, []string{report.JunitFormat}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, report.JunitFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read report.JunitTestSuites
+	if err := xml.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Tests != 4 || read.Failures != 1 || len(read.Suites) != 3 {
+		t.Fatalf("bad test suites: %v", read)
+	}
+
+	v := read.Suites[0]
+	if v.Name != report.JunitValidationSuite || v.Tests != 1 || v.Failures != 0 {
+		t.Errorf("expected passing validation suite, found %v", v)
+	}
+	s := read.Suites[1]
+	if s.Name != "a.rules.json" || s.Tests != 2 || s.Failures != 1 || s.Timestamp != "2024-05-01T10:00:00Z" {
+		t.Errorf("bad rule file suite: %v", s)
+	}
+	if c := s.Cases[0]; c.ClassName != "r1" || c.Name != "o1 conformity" || c.Failure != nil {
+		t.Errorf("bad passing case: %v", c)
+	}
+	c := s.Cases[1]
+	if c.Failure == nil || c.Failure.Type != "error" || c.Failure.Message != "Mismatch for o2: first" ||
+		c.Failure.Detail != "Mismatch for o2: first\nMismatch for o2: second" {
+		t.Errorf("bad failing case: %v", c)
+	}
+	g := read.Suites[2]
+	if g.Name != report.JunitRulesSuite || len(g.Cases) != 1 || g.Cases[0].ClassName != "g1" || g.Cases[0].Name != "s1 convergence size" {
+		t.Errorf("bad group suite: %v", g)
+	}
+
+	summary := rep.Summary()
+	if summary.Checks.Passed != 2 || summary.Checks.Failed != 1 {
+		t.Errorf("bad check counts: %v", summary.Checks)
+	}
+}
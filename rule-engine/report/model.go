@@ -0,0 +1,208 @@
+// Under the Apache-2.0 License
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/engine/runner"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// Version of the report file format.  Increment this on incompatible changes.
+const Version = 1
+
+const (
+	ReportFile  = "qazaar-report.json"
+	SummaryFile = "qazaar-summary.json"
+)
+
+// Phases of the run that generate problems.
+const (
+	ValidationPhase = "validation"
+	EnginePhase     = "engine"
+)
+
+// Report contains all the results of a single run.
+type Report struct {
+	Version   int              `json:"version"`
+	Generated time.Time        `json:"generated"`
+	Inputs    *ingest.Manifest `json:"inputs"`
+	Problems  []Problem        `json:"problems"`
+
+	// Halted explains why the halting policy stopped the engine early.  The
+	// checks and problems of a halted run are partial.
+	Halted string `json:"halted,omitempty"`
+
+	// Checks contains every rule and group evaluation, including the passed ones.
+	// This can be very large, so only the counts go into the summary.
+	Checks []runner.Check `json:"-"`
+}
+
+// Problem is the report form of a single problem.
+type Problem struct {
+	Phase     string   `json:"phase"`
+	Level     string   `json:"level"`
+	Message   string   `json:"message"`
+	RuleId    string   `json:"ruleId,omitempty"`
+	GroupId   string   `json:"groupId,omitempty"`
+	SogId     string   `json:"sogId,omitempty"`
+	ObjectIds []string `json:"objectIds,omitempty"`
+	Sources   []Source `json:"sources"`
+	Origin    *Origin  `json:"origin,omitempty"`
+
+	level problem.ProblemLevel
+}
+
+// Origin is the report form of the input file location that caused the problem.
+type Origin struct {
+	File    string `json:"file"`
+	Pointer string `json:"pointer"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// Source is the report form of a source reference.
+type Source struct {
+	Rep    string  `json:"rep"`
+	Loc    string  `json:"loc"`
+	Ver    *string `json:"ver,omitempty"`
+	Anchor *string `json:"a,omitempty"`
+}
+
+// Summary contains the problem counts of a single run.
+type Summary struct {
+	Version   int            `json:"version"`
+	Generated time.Time      `json:"generated"`
+	Counts    map[string]int `json:"counts"`
+	Total     int            `json:"total"`
+	Passed    bool           `json:"passed"`
+	Halted    string         `json:"halted,omitempty"`
+	Checks    CheckCounts    `json:"checks"`
+}
+
+// CheckCounts contains the number of passed and failed rule and group evaluations.
+type CheckCounts struct {
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// New creates a new, empty report for the inputs.
+func New(inputs *ingest.Manifest, generated time.Time) *Report {
+	if inputs == nil {
+		inputs = &ingest.Manifest{}
+	}
+	return &Report{
+		Version:   Version,
+		Generated: generated,
+		Inputs:    inputs,
+		Problems:  make([]Problem, 0),
+	}
+}
+
+// Add adds the problems found in the run phase.
+func (r *Report) Add(phase string, probs *problem.ProblemSet) {
+	if r == nil || probs == nil {
+		return
+	}
+	for _, p := range probs.Problems() {
+		rp := Problem{
+			Phase:   phase,
+			Level:   p.Level.String(),
+			Message: p.Message,
+			Sources: AsSources(p.Sources),
+			level:   p.Level,
+		}
+		if !p.Origin.IsZero() {
+			rp.Origin = &Origin{
+				File:    p.Origin.File,
+				Pointer: p.Origin.Pointer,
+				Line:    p.Origin.Line,
+				Column:  p.Origin.Column,
+			}
+		}
+		if s := p.Subject(); s != nil {
+			rp.RuleId = s.RuleId
+			rp.GroupId = s.GroupId
+			rp.SogId = s.SogId
+			rp.ObjectIds = s.ObjectIds
+		}
+		r.Problems = append(r.Problems, rp)
+	}
+}
+
+// AddChecks adds the rule and group evaluations performed by the engine, and whether the engine halted early.
+func (r *Report) AddChecks(res *runner.Results) {
+	if r == nil || res == nil {
+		return
+	}
+	r.Checks = append(r.Checks, res.Checks()...)
+	if h := res.Halted(); h != "" {
+		r.Halted = h
+	}
+}
+
+// Sort orders the problems by phase, then by decreasing level, then by message.
+//
+// The engine generates problems asynchronously, so this keeps the report stable
+// between runs.
+func (r *Report) Sort() {
+	phaseOrder := map[string]int{ValidationPhase: 0, EnginePhase: 1}
+	sort.SliceStable(r.Problems, func(i, j int) bool {
+		a, b := r.Problems[i], r.Problems[j]
+		if a.Phase != b.Phase {
+			return phaseOrder[a.Phase] < phaseOrder[b.Phase]
+		}
+		if a.level != b.level {
+			return a.level > b.level
+		}
+		return a.Message < b.Message
+	})
+}
+
+// Summary counts the problems in the report.
+func (r *Report) Summary() *Summary {
+	ret := &Summary{
+		Version:   Version,
+		Generated: r.Generated,
+		Counts: map[string]int{
+			problem.Quiet.String(): 0,
+			problem.Info.String():  0,
+			problem.Warn.String():  0,
+			problem.Err.String():   0,
+		},
+		Total:  len(r.Problems),
+		Passed: r.Halted == "",
+		Halted: r.Halted,
+	}
+	for _, p := range r.Problems {
+		ret.Counts[p.Level]++
+		if p.level == problem.Err {
+			ret.Passed = false
+		}
+	}
+	for _, c := range r.Checks {
+		if c.Passed() {
+			ret.Checks.Passed++
+		} else {
+			ret.Checks.Failed++
+		}
+	}
+	return ret
+}
+
+// AsSources converts the sources into the report form.
+func AsSources(src []sources.Source) []Source {
+	ret := make([]Source, len(src))
+	for i, s := range src {
+		ret[i] = Source{
+			Rep:    s.Rep(),
+			Loc:    s.Loc(),
+			Ver:    s.Ver(),
+			Anchor: s.A(),
+		}
+	}
+	return ret
+}
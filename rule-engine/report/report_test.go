@@ -0,0 +1,115 @@
+// Under the Apache-2.0 License
+package report_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/report"
+)
+
+type subjectContext struct{}
+
+func (subjectContext) Subject() problem.Subject {
+	return problem.Subject{RuleId: "r1", ObjectIds: []string{"o1"}}
+}
+
+func Test_WriteJson(t *testing.T) {
+	docs := sampleDocs(t)
+
+	validation := problem.New()
+	validation.AddWarning(nil, "unused variable")
+	engine := problem.New()
+	engine.Add(problem.Problem{
+		Level:   problem.Err,
+		Message: "Rule r1 violation",
+		Sources: docs.Objects[0].Sources,
+		Context: subjectContext{},
+	})
+
+	generated := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rep := report.New(&ingest.Manifest{Rules: []string{"rules.json"}, Documents: []string{"doc.json"}}, generated)
+	rep.Add(report.EnginePhase, engine)
+	rep.Add(report.ValidationPhase, validation)
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := rep.WriteJson(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	var read report.Report
+	readJson(t, filepath.Join(dir, report.ReportFile), &read)
+	if read.Version != report.Version || !read.Generated.Equal(generated) {
+		t.Errorf("bad report header: %v", read)
+	}
+	if diff := cmp.Diff([]string{"rules.json"}, read.Inputs.Rules); diff != "" {
+		t.Errorf("input mismatch (-want +got):\n%s", diff)
+	}
+	if len(read.Problems) != 2 {
+		t.Fatalf("expected 2 problems, found %v", read.Problems)
+	}
+	if read.Problems[0].Phase != report.ValidationPhase || read.Problems[0].Level != "warning" {
+		t.Errorf("expected the validation problem first, found %v", read.Problems[0])
+	}
+	p := read.Problems[1]
+	if p.Level != "error" || p.RuleId != "r1" || p.GroupId != "" || len(p.ObjectIds) != 1 || p.ObjectIds[0] != "o1" {
+		t.Errorf("bad engine problem: %v", p)
+	}
+	if len(p.Sources) != 1 || p.Sources[0].Loc != "req.md" || *p.Sources[0].Ver != "v1" || *p.Sources[0].Anchor != "lines:10-19" {
+		t.Errorf("bad engine problem sources: %v", p.Sources)
+	}
+
+	var summary report.Summary
+	readJson(t, filepath.Join(dir, report.SummaryFile), &summary)
+	expected := map[string]int{"quiet": 0, "info": 0, "warning": 1, "error": 1}
+	if diff := cmp.Diff(expected, summary.Counts); diff != "" {
+		t.Errorf("count mismatch (-want +got):\n%s", diff)
+	}
+	if summary.Total != 2 || summary.Passed || summary.Halted != "" {
+		t.Errorf("bad summary: %v", summary)
+	}
+}
+
+func Test_Summary_Halted(t *testing.T) {
+	rep := report.New(nil, time.Now())
+	rep.Halted = "reached 1 failed checks at the error level"
+	summary := rep.Summary()
+	if summary.Passed || summary.Halted != rep.Halted {
+		t.Errorf("expected a failed, halted summary, found %v", summary)
+	}
+	run := rep.Sarif().Runs[0]
+	if len(run.Invocations) != 1 || run.Invocations[0].ExecutionSuccessful {
+		t.Errorf("expected an unsuccessful invocation, found %v", run.Invocations)
+	}
+}
+
+func sampleDocs(t *testing.T) *sdoc.Documents {
+	src, err := ingest.ParseDocuments(strings.NewReader(`{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "s", "rep": "git", "loc": "req.md", "ver": "v1"}],
+		"objects": [{"id": "o1", "sources": [{"ref": "s", "a": "lines:10-19"}], "descriptors": []}]
+	}`), "doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := sdoc.New()
+	docs.Add(src)
+	return docs
+}
+
+func readJson(t *testing.T, f string, v any) {
+	data, err := os.ReadFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
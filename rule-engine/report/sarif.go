@@ -0,0 +1,289 @@
+// Under the Apache-2.0 License
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+const (
+	SarifFile    = "qazaar-report.sarif"
+	SarifVersion = "2.1.0"
+	SarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	ToolName     = "qazaar-rule-engine"
+	ToolUri      = "https://github.com/groboclown/qazaar-testing"
+)
+
+// Reporting descriptor kinds, stored in the descriptor properties.
+const (
+	RuleKind  = "rule"
+	GroupKind = "group"
+)
+
+// GroupIdPrefix starts the reporting descriptor id of a group.
+//
+// Rules and groups have separate ids, so a rule and a group may share one;
+// the prefix keeps their reporting descriptors apart.
+const GroupIdPrefix = "group:"
+
+// SarifLog is the top level SARIF 2.1.0 document.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// SarifRun contains the results of a single tool run.
+type SarifRun struct {
+	Tool        SarifTool         `json:"tool"`
+	Invocations []SarifInvocation `json:"invocations,omitempty"`
+	Results     []SarifResult     `json:"results"`
+}
+
+// SarifInvocation describes how the run ended; it is only reported for a halted run.
+type SarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []SarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type SarifNotification struct {
+	Level   string       `json:"level"`
+	Message SarifMessage `json:"message"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationUri string                     `json:"informationUri,omitempty"`
+	Rules          []SarifReportingDescriptor `json:"rules"`
+}
+
+// SarifReportingDescriptor describes a single rule or group.
+type SarifReportingDescriptor struct {
+	Id         string            `json:"id"`
+	Name       string            `json:"name,omitempty"` // The rule or group id.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// SarifResult is a single problem.
+type SarifResult struct {
+	RuleId     string          `json:"ruleId,omitempty"`
+	RuleIndex  *int            `json:"ruleIndex,omitempty"`
+	Level      string          `json:"level"`
+	Message    SarifMessage    `json:"message"`
+	Locations  []SarifLocation `json:"locations,omitempty"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+// SarifArtifactLocation refers to the source file.
+//
+// The source repository, version, and anchor don't have a direct SARIF
+// equivalent, so they are kept in the properties.
+type SarifArtifactLocation struct {
+	Uri        string            `json:"uri"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+}
+
+// WriteSarif writes the report as a SARIF file into the directory, creating the directory if necessary.
+func (r *Report) WriteSarif(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return writeJsonFile(filepath.Join(dir, SarifFile), r.Sarif())
+}
+
+// Sarif converts the report into the SARIF form.
+//
+// Each rule or group with a problem becomes a reporting descriptor, and each
+// problem becomes a result.
+func (r *Report) Sarif() *SarifLog {
+	r.Sort()
+	descriptors := sarifDescriptors(r.Problems)
+	index := make(map[string]int, len(descriptors))
+	for i, d := range descriptors {
+		index[d.Id] = i
+	}
+
+	results := make([]SarifResult, len(r.Problems))
+	for i, p := range r.Problems {
+		res := SarifResult{
+			Level:     sarifLevel(p.level),
+			Message:   SarifMessage{Text: p.Message},
+			Locations: sarifLocations(p.Sources, p.Origin),
+			Properties: map[string]any{
+				"phase": p.Phase,
+			},
+		}
+		if id := sarifRuleId(p); id != "" {
+			idx := index[id]
+			res.RuleId = id
+			res.RuleIndex = &idx
+		}
+		if p.SogId != "" {
+			res.Properties["sogId"] = p.SogId
+		}
+		if len(p.ObjectIds) > 0 {
+			res.Properties["objectIds"] = p.ObjectIds
+		}
+		results[i] = res
+	}
+
+	run := SarifRun{
+		Tool: SarifTool{Driver: SarifDriver{
+			Name:           ToolName,
+			InformationUri: ToolUri,
+			Rules:          descriptors,
+		}},
+		Results: results,
+	}
+	if r.Halted != "" {
+		run.Invocations = []SarifInvocation{{
+			ExecutionSuccessful: false,
+			ToolExecutionNotifications: []SarifNotification{{
+				Level:   "error",
+				Message: SarifMessage{Text: "Engine halted: " + r.Halted},
+			}},
+		}}
+	}
+
+	return &SarifLog{
+		Schema:  SarifSchema,
+		Version: SarifVersion,
+		Runs:    []SarifRun{run},
+	}
+}
+
+// ParseLineAnchor extracts the line range from a 'line:N' or 'lines:A-B' anchor.
+//
+// Returns nil if the anchor isn't a line anchor.
+func ParseLineAnchor(anchor string) *SarifRegion {
+	if v, ok := strings.CutPrefix(anchor, "line:"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || n <= 0 {
+			return nil
+		}
+		return &SarifRegion{StartLine: n, EndLine: n}
+	}
+	if v, ok := strings.CutPrefix(anchor, "lines:"); ok {
+		a, b, found := strings.Cut(v, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(a))
+		if err != nil || start <= 0 {
+			return nil
+		}
+		if !found {
+			return &SarifRegion{StartLine: start, EndLine: start}
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(b))
+		if err != nil || end < start {
+			return nil
+		}
+		return &SarifRegion{StartLine: start, EndLine: end}
+	}
+	return nil
+}
+
+// sarifRuleId returns the reporting descriptor id of the problem's rule or group.
+func sarifRuleId(p Problem) string {
+	if p.RuleId != "" {
+		return p.RuleId
+	}
+	if p.GroupId != "" {
+		return GroupIdPrefix + p.GroupId
+	}
+	return ""
+}
+
+// sarifDescriptors returns the sorted, distinct rules and groups referenced by the problems.
+func sarifDescriptors(probs []Problem) []SarifReportingDescriptor {
+	byId := make(map[string]SarifReportingDescriptor)
+	for _, p := range probs {
+		id := sarifRuleId(p)
+		if _, ok := byId[id]; ok || id == "" {
+			continue
+		}
+		if p.RuleId != "" {
+			byId[id] = SarifReportingDescriptor{Id: id, Name: p.RuleId, Properties: map[string]string{"kind": RuleKind}}
+		} else {
+			byId[id] = SarifReportingDescriptor{Id: id, Name: p.GroupId, Properties: map[string]string{"kind": GroupKind}}
+		}
+	}
+	ret := make([]SarifReportingDescriptor, 0, len(byId))
+	for _, d := range byId {
+		ret = append(ret, d)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Id < ret[j].Id })
+	return ret
+}
+
+// sarifLocations converts the sources into locations.
+//
+// Problems without sources, such as a bad rule, point to the input file instead.
+func sarifLocations(src []Source, origin *Origin) []SarifLocation {
+	if len(src) == 0 && origin != nil && origin.File != "" {
+		loc := SarifLocation{PhysicalLocation: SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{
+				Uri:        origin.File,
+				Properties: map[string]string{"pointer": origin.Pointer},
+			},
+		}}
+		if origin.Line > 0 {
+			loc.PhysicalLocation.Region = &SarifRegion{StartLine: origin.Line, StartColumn: origin.Column}
+		}
+		return []SarifLocation{loc}
+	}
+	ret := make([]SarifLocation, 0, len(src))
+	for _, s := range src {
+		props := map[string]string{"rep": s.Rep}
+		loc := SarifLocation{PhysicalLocation: SarifPhysicalLocation{
+			ArtifactLocation: SarifArtifactLocation{Uri: s.Loc, Properties: props},
+		}}
+		if s.Ver != nil {
+			props["ver"] = *s.Ver
+		}
+		if s.Anchor != nil {
+			props["a"] = *s.Anchor
+			loc.PhysicalLocation.Region = ParseLineAnchor(*s.Anchor)
+		}
+		ret = append(ret, loc)
+	}
+	return ret
+}
+
+func sarifLevel(l problem.ProblemLevel) string {
+	switch l {
+	case problem.Err:
+		return "error"
+	case problem.Warn:
+		return "warning"
+	case problem.Info:
+		return "note"
+	}
+	return "none"
+}
@@ -0,0 +1,164 @@
+// Under the Apache-2.0 License
+package report_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/report"
+)
+
+type groupContext struct{}
+
+func (groupContext) Subject() problem.Subject {
+	return problem.Subject{GroupId: "g1"}
+}
+
+func Test_ParseLineAnchor(t *testing.T) {
+	for anchor, expected := range map[string]*report.SarifRegion{
+		"line:76":      {StartLine: 76, EndLine: 76},
+		"lines:10-19":  {StartLine: 10, EndLine: 19},
+		"lines:4":      {StartLine: 4, EndLine: 4},
+		"lines:19-10":  nil,
+		"line:x":       nil,
+		"line:0":       nil,
+		"#section-one": nil,
+	} {
+		if diff := cmp.Diff(expected, report.ParseLineAnchor(anchor)); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", anchor, diff)
+		}
+	}
+}
+
+func Test_WriteSarif(t *testing.T) {
+	docs := sampleDocs(t)
+
+	engine := problem.New()
+	engine.Add(problem.Problem{
+		Level:   problem.Err,
+		Message: "Rule r1 violation",
+		Sources: docs.Objects[0].Sources,
+		Context: subjectContext{},
+	})
+	engine.Add(problem.Problem{
+		Level:   problem.Warn,
+		Message: "Group g1 violation",
+		Sources: docs.Objects[0].Sources,
+		Context: groupContext{},
+	})
+	validation := problem.New()
+	validation.AddInfo(nil, "note")
+
+	rep := report.New(&ingest.Manifest{}, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	rep.Add(report.EnginePhase, engine)
+	rep.Add(report.ValidationPhase, validation)
+	dir := t.TempDir()
+	if err := rep.Write(dir, []string{report.SarifFormat}); err != nil {
+		t.Fatal(err)
+	}
+
+	var read report.SarifLog
+	readJson(t, filepath.Join(dir, report.SarifFile), &read)
+	if read.Version != report.SarifVersion || len(read.Runs) != 1 {
+		t.Fatalf("bad SARIF header: %v", read)
+	}
+	run := read.Runs[0]
+	expected := []report.SarifReportingDescriptor{
+		{Id: "group:g1", Name: "g1", Properties: map[string]string{"kind": report.GroupKind}},
+		{Id: "r1", Name: "r1", Properties: map[string]string{"kind": report.RuleKind}},
+	}
+	if diff := cmp.Diff(expected, run.Tool.Driver.Rules); diff != "" {
+		t.Errorf("rule mismatch (-want +got):\n%s", diff)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, found %v", run.Results)
+	}
+	if r := run.Results[0]; r.Level != "note" || r.RuleId != "" || r.RuleIndex != nil || len(r.Locations) != 0 {
+		t.Errorf("bad validation result: %v", r)
+	}
+	r := run.Results[1]
+	if r.Level != "error" || r.RuleId != "r1" || *r.RuleIndex != 1 || r.Message.Text != "Rule r1 violation" {
+		t.Errorf("bad rule result: %v", r)
+	}
+	if len(r.Locations) != 1 {
+		t.Fatalf("expected 1 location, found %v", r.Locations)
+	}
+	loc := r.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.Uri != "req.md" || loc.ArtifactLocation.Properties["rep"] != "git" || loc.ArtifactLocation.Properties["ver"] != "v1" {
+		t.Errorf("bad artifact location: %v", loc.ArtifactLocation)
+	}
+	if diff := cmp.Diff(&report.SarifRegion{StartLine: 10, EndLine: 19}, loc.Region); diff != "" {
+		t.Errorf("region mismatch (-want +got):\n%s", diff)
+	}
+	if r := run.Results[2]; r.Level != "warning" || r.RuleId != "group:g1" || *r.RuleIndex != 0 {
+		t.Errorf("bad group result: %v", r)
+	}
+}
+
+type sameIdContext struct{}
+
+func (sameIdContext) Subject() problem.Subject {
+	return problem.Subject{RuleId: "g1"}
+}
+
+func Test_WriteSarif_SameRuleAndGroupId(t *testing.T) {
+	engine := problem.New()
+	engine.Add(problem.Problem{Level: problem.Err, Message: "Group g1 violation", Context: groupContext{}})
+	engine.Add(problem.Problem{Level: problem.Err, Message: "Rule g1 violation", Context: sameIdContext{}})
+	rep := report.New(&ingest.Manifest{}, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	rep.Add(report.EnginePhase, engine)
+	dir := t.TempDir()
+	if err := rep.Write(dir, []string{report.SarifFormat}); err != nil {
+		t.Fatal(err)
+	}
+
+	var read report.SarifLog
+	readJson(t, filepath.Join(dir, report.SarifFile), &read)
+	run := read.Runs[0]
+	expected := []report.SarifReportingDescriptor{
+		{Id: "g1", Name: "g1", Properties: map[string]string{"kind": report.RuleKind}},
+		{Id: "group:g1", Name: "g1", Properties: map[string]string{"kind": report.GroupKind}},
+	}
+	if diff := cmp.Diff(expected, run.Tool.Driver.Rules); diff != "" {
+		t.Errorf("rule mismatch (-want +got):\n%s", diff)
+	}
+	prefix := map[string]string{report.RuleKind: "Rule", report.GroupKind: "Group"}
+	for _, r := range run.Results {
+		d := run.Tool.Driver.Rules[*r.RuleIndex]
+		if d.Id != r.RuleId || !strings.HasPrefix(r.Message.Text, prefix[d.Properties["kind"]]) {
+			t.Errorf("result %s points to %v", r.Message.Text, d)
+		}
+	}
+}
+
+func Test_Sarif_Origin(t *testing.T) {
+	probs := problem.New()
+	probs.AddProblemAt(
+		sources.Origin{File: "a.rule.json", Pointer: "/rules/0", Line: 3, Column: 5},
+		nil,
+		problem.Err,
+		"undefined key",
+	)
+	r := report.New(nil, time.Now())
+	r.Add("validate", probs)
+	if r.Problems[0].Origin == nil || r.Problems[0].Origin.Pointer != "/rules/0" {
+		t.Fatalf("bad report origin: %v", r.Problems[0].Origin)
+	}
+
+	expected := []report.SarifLocation{{PhysicalLocation: report.SarifPhysicalLocation{
+		ArtifactLocation: report.SarifArtifactLocation{
+			Uri:        "a.rule.json",
+			Properties: map[string]string{"pointer": "/rules/0"},
+		},
+		Region: &report.SarifRegion{StartLine: 3, StartColumn: 5},
+	}}}
+	if diff := cmp.Diff(expected, r.Sarif().Runs[0].Results[0].Locations); diff != "" {
+		t.Errorf("locations mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -310,6 +310,12 @@ type ContainsMatcher struct {
 	// Key corresponds to the JSON schema field "key".
 	Key DescriptorKey `json:"key" yaml:"key" mapstructure:"key"`
 
+	// For a SOG, matches on the values of its members rather than the values of the
+	// SOG itself.  union: matches on the distinct values across all the members.
+	// each: every member's values must match.  An object that is not a SOG is its own
+	// single member.
+	Members *ContainsMatcherMembers `json:"members,omitempty" yaml:"members,omitempty" mapstructure:"members,omitempty"`
+
 	// Sources corresponds to the JSON schema field "sources".
 	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
 
@@ -320,6 +326,36 @@ type ContainsMatcher struct {
 	Values ValueCheckList `json:"values" yaml:"values" mapstructure:"values"`
 }
 
+type ContainsMatcherMembers string
+
+const ContainsMatcherMembersEach ContainsMatcherMembers = "each"
+const ContainsMatcherMembersUnion ContainsMatcherMembers = "union"
+
+var enumValues_ContainsMatcherMembers = []interface{}{
+	"union",
+	"each",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ContainsMatcherMembers) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_ContainsMatcherMembers {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_ContainsMatcherMembers, v)
+	}
+	*j = ContainsMatcherMembers(v)
+	return nil
+}
+
 type ContainsMatcherType string
 
 const ContainsMatcherTypeContainsAll ContainsMatcherType = "containsAll"
@@ -392,6 +428,9 @@ type ConvergenceImplication struct {
 	// Comments corresponds to the JSON schema field "$comments".
 	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
 
+	// For 'atLeastNDistinct', the minimum number of different member values.
+	Count *int `json:"count,omitempty" yaml:"count,omitempty" mapstructure:"count,omitempty"`
+
 	// True means to examine the values within each member as distinct (they only
 	// appear at most once).
 	Distinct bool `json:"distinct,omitempty" yaml:"distinct,omitempty" mapstructure:"distinct,omitempty"`
@@ -402,23 +441,48 @@ type ConvergenceImplication struct {
 	// Level corresponds to the JSON schema field "level".
 	Level ImplicationLevel `json:"level" yaml:"level" mapstructure:"level"`
 
+	// For 'subsetOf' and 'supersetOf', selects the reference members; for example,
+	// the member that is the source of truth.  The other members are checked against
+	// the union of the reference members' values.
+	Reference MatcherCollection `json:"reference,omitempty" yaml:"reference,omitempty" mapstructure:"reference,omitempty"`
+
 	// How the descriptor's values must align between the SOG members. 'allMatch'
 	// means that each member's values must all be identical.  'disjoint' means that
-	// each value can exist in, at most, one member.
+	// each value can exist in, at most, one member.  'subsetOf' means that each
+	// member's values must be within the reference members' values.  'supersetOf'
+	// means that each member's values must include all the reference members' values.
+	// 'atLeastNDistinct' means that the members must have at least 'count' different
+	// values.  'withinTolerance' means that the numeric values across all members may
+	// differ by at most 'tolerance'.  'majority' means that each member's values must
+	// match the most common member values; only the members that differ are reported.
 	Requires ConvergenceImplicationRequires `json:"requires" yaml:"requires" mapstructure:"requires"`
 
 	// Sources corresponds to the JSON schema field "sources".
 	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+
+	// For 'withinTolerance', the largest allowed difference between the smallest and
+	// largest value across all the members.
+	Tolerance *float64 `json:"tolerance,omitempty" yaml:"tolerance,omitempty" mapstructure:"tolerance,omitempty"`
 }
 
 type ConvergenceImplicationRequires string
 
 const ConvergenceImplicationRequiresAllMatch ConvergenceImplicationRequires = "allMatch"
+const ConvergenceImplicationRequiresAtLeastNDistinct ConvergenceImplicationRequires = "atLeastNDistinct"
 const ConvergenceImplicationRequiresDisjoint ConvergenceImplicationRequires = "disjoint"
+const ConvergenceImplicationRequiresMajority ConvergenceImplicationRequires = "majority"
+const ConvergenceImplicationRequiresSubsetOf ConvergenceImplicationRequires = "subsetOf"
+const ConvergenceImplicationRequiresSupersetOf ConvergenceImplicationRequires = "supersetOf"
+const ConvergenceImplicationRequiresWithinTolerance ConvergenceImplicationRequires = "withinTolerance"
 
 var enumValues_ConvergenceImplicationRequires = []interface{}{
 	"allMatch",
 	"disjoint",
+	"subsetOf",
+	"supersetOf",
+	"atLeastNDistinct",
+	"withinTolerance",
+	"majority",
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -468,6 +532,60 @@ func (j *ConvergenceImplication) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// A requirement that other items cover the item matching a rule.  A counterpart
+// item covers the matching item's descriptor value when it matches the counterpart
+// matchers and contains the same value for the descriptor key.
+type CoverageImplication struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// Counterpart corresponds to the JSON schema field "counterpart".
+	Counterpart MatcherCollection `json:"counterpart" yaml:"counterpart" mapstructure:"counterpart"`
+
+	// Key corresponds to the JSON schema field "key".
+	Key DescriptorKey `json:"key" yaml:"key" mapstructure:"key"`
+
+	// Level corresponds to the JSON schema field "level".
+	Level ImplicationLevel `json:"level" yaml:"level" mapstructure:"level"`
+
+	// Minimum number of counterpart items that must share each of the matching item's
+	// values for the descriptor key.  Defaults to 1.
+	Minimum int `json:"minimum,omitempty" yaml:"minimum,omitempty" mapstructure:"minimum,omitempty"`
+
+	// Sources corresponds to the JSON schema field "sources".
+	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *CoverageImplication) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["counterpart"]; raw != nil && !ok {
+		return fmt.Errorf("field counterpart in CoverageImplication: required")
+	}
+	if _, ok := raw["key"]; raw != nil && !ok {
+		return fmt.Errorf("field key in CoverageImplication: required")
+	}
+	if _, ok := raw["level"]; raw != nil && !ok {
+		return fmt.Errorf("field level in CoverageImplication: required")
+	}
+	type Plain CoverageImplication
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["minimum"]; !ok || v == nil {
+		plain.Minimum = 1.0
+	}
+	*j = CoverageImplication(plain)
+	return nil
+}
+
 // Unique identifier for the descriptor.
 type DescriptorKey string
 
@@ -494,6 +612,9 @@ type Group struct {
 	// List of descriptor alterations to perform on the generated SOG.
 	Alterations []Alteration `json:"alterations,omitempty" yaml:"alterations,omitempty" mapstructure:"alterations,omitempty"`
 
+	// List of conformity implications on each generated SOG, after the alterations.
+	Conformities []ConformityImplication `json:"conformities,omitempty" yaml:"conformities,omitempty" mapstructure:"conformities,omitempty"`
+
 	// List of SOG convergence implications.
 	Convergences []ConvergenceImplication `json:"convergences,omitempty" yaml:"convergences,omitempty" mapstructure:"convergences,omitempty"`
 
@@ -702,6 +823,9 @@ type Rule struct {
 	// List of conformity implications.
 	Conformities []ConformityImplication `json:"conformities,omitempty" yaml:"conformities,omitempty" mapstructure:"conformities,omitempty"`
 
+	// List of coverage implications.
+	Coverages []CoverageImplication `json:"coverages,omitempty" yaml:"coverages,omitempty" mapstructure:"coverages,omitempty"`
+
 	// Id corresponds to the JSON schema field "id".
 	Id Id `json:"id" yaml:"id" mapstructure:"id"`
 
@@ -898,6 +1022,75 @@ func (j *StringCheck) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Ensures no two members of a SOG share a value for the descriptor.  Members
+// without a value for the descriptor do not conflict.  An object that is not a SOG
+// is its own single member, so it always matches.
+type UniqueMatcher struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// Key corresponds to the JSON schema field "key".
+	Key DescriptorKey `json:"key" yaml:"key" mapstructure:"key"`
+
+	// Sources corresponds to the JSON schema field "sources".
+	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+
+	// The type of descriptor matcher defined by this definition.
+	Type UniqueMatcherType `json:"type" yaml:"type" mapstructure:"type"`
+}
+
+type UniqueMatcherType string
+
+const UniqueMatcherTypeUnique UniqueMatcherType = "unique"
+
+var enumValues_UniqueMatcherType = []interface{}{
+	"unique",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *UniqueMatcherType) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_UniqueMatcherType {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_UniqueMatcherType, v)
+	}
+	*j = UniqueMatcherType(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *UniqueMatcher) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["key"]; raw != nil && !ok {
+		return fmt.Errorf("field key in UniqueMatcher: required")
+	}
+	if _, ok := raw["type"]; raw != nil && !ok {
+		return fmt.Errorf("field type in UniqueMatcher: required")
+	}
+	type Plain UniqueMatcher
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = UniqueMatcher(plain)
+	return nil
+}
+
 type ValueCheck interface{}
 
 // List of checks for a descriptor's value.  The descriptor value type must match
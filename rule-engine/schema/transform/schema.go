@@ -0,0 +1,432 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package transform
+
+import "encoding/json"
+import "fmt"
+import "reflect"
+
+// Document author comment text.
+type Comment string
+
+// List of document author comments.
+type CommentList []Comment
+
+// A shared primary document reference.  Source locations can refer to this
+// document through the identifier, but should also include an anchor.
+type CommonDocumentSource struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id Id `json:"id" yaml:"id" mapstructure:"id"`
+
+	// The resource identifier within the 'repo'.  Depending on the repo type, this
+	// most likely has a required format for that repository.
+	Loc string `json:"loc" yaml:"loc" mapstructure:"loc"`
+
+	// General repository category containing the source.  This might be 'git' if
+	// stored in a Git repository, or 'aws-s3', if stored in an Amazon S3 key store,
+	// or 'intranet' if stored in an Intranet source.  The different programs may have
+	// their own requirements for this value.  It does not define a location within
+	// the repository, though.
+	Rep string `json:"rep" yaml:"rep" mapstructure:"rep"`
+
+	// An identifier to reference the unique version of the source, as dictated by the
+	// repository type.  This might a commit id, or document revision, or a date-time
+	// stamp.  The repository type may not have the ability to retrieve this version
+	// (someone may have deleted it, or the repository does not support versioning).
+	Ver *string `json:"ver,omitempty" yaml:"ver,omitempty" mapstructure:"ver,omitempty"`
+}
+
+// Pool of document source references, which may be referenced from the source
+// locations.
+type CommonDocumentSourceList []CommonDocumentSource
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *CommonDocumentSource) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in CommonDocumentSource: required")
+	}
+	if _, ok := raw["loc"]; raw != nil && !ok {
+		return fmt.Errorf("field loc in CommonDocumentSource: required")
+	}
+	if _, ok := raw["rep"]; raw != nil && !ok {
+		return fmt.Errorf("field rep in CommonDocumentSource: required")
+	}
+	type Plain CommonDocumentSource
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if len(plain.Loc) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "loc", 1)
+	}
+	if len(plain.Loc) > 8000 {
+		return fmt.Errorf("field %s length: must be <= %d", "loc", 8000)
+	}
+	if len(plain.Rep) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "rep", 1)
+	}
+	if len(plain.Rep) > 200 {
+		return fmt.Errorf("field %s length: must be <= %d", "rep", 200)
+	}
+	if plain.Ver != nil && len(*plain.Ver) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "ver", 1)
+	}
+	if plain.Ver != nil && len(*plain.Ver) > 200 {
+		return fmt.Errorf("field %s length: must be <= %d", "ver", 200)
+	}
+	*j = CommonDocumentSource(plain)
+	return nil
+}
+
+// Unique identifier for the descriptor.
+type DescriptorKey string
+
+// A numeric value.
+type DescriptorNumericValue float64
+
+// A textual value, either an enumerated or free value.
+type DescriptorTextValue string
+
+type DescriptorValue interface{}
+
+// Sources that contained the original definitions.  A tool collected those
+// descriptions into this document.
+type DocumentSources []SourceLocation
+
+// Unique identifying string for the item.  These should be ASCII alpha-numeric +
+// simple separators.
+type Id string
+
+// Describes how to turn one set of ontology descriptors into another.  STATUS: in
+// development
+type OntologyTransformV1SchemaJson struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// Schema corresponds to the JSON schema field "$schema".
+	Schema Schema `json:"$schema" yaml:"$schema" mapstructure:"$schema"`
+
+	// CommonSourceRefs corresponds to the JSON schema field "commonSourceRefs".
+	CommonSourceRefs CommonDocumentSourceList `json:"commonSourceRefs,omitempty" yaml:"commonSourceRefs,omitempty" mapstructure:"commonSourceRefs,omitempty"`
+
+	// Sources corresponds to the JSON schema field "sources".
+	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+
+	// Collection of ontology transformations.  If required, additional transforms
+	// should live in adjacent documents.
+	Transforms []Transform `json:"transforms" yaml:"transforms" mapstructure:"transforms"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OntologyTransformV1SchemaJson) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["$schema"]; raw != nil && !ok {
+		return fmt.Errorf("field $schema in OntologyTransformV1SchemaJson: required")
+	}
+	if _, ok := raw["transforms"]; raw != nil && !ok {
+		return fmt.Errorf("field transforms in OntologyTransformV1SchemaJson: required")
+	}
+	type Plain OntologyTransformV1SchemaJson
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = OntologyTransformV1SchemaJson(plain)
+	return nil
+}
+
+// Data exchange schema format.
+type Schema string
+
+// Pointer to the location of the source.  Due to the prevalence of this object,
+// property names use a truncated form to shrink file sizes.  The 'ref' points to a
+// common document source identifier in the commonSourceRefs list.
+type SourceLocation struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// A location within the source.  This depends upon the source type; it might be
+	// an HTML anchor tag, or a paragraph title, or a function name, or a line number,
+	// or an opcode index.
+	A *string `json:"a,omitempty" yaml:"a,omitempty" mapstructure:"a,omitempty"`
+
+	// Ref corresponds to the JSON schema field "ref".
+	Ref Id `json:"ref" yaml:"ref" mapstructure:"ref"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *SourceLocation) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["ref"]; raw != nil && !ok {
+		return fmt.Errorf("field ref in SourceLocation: required")
+	}
+	type Plain SourceLocation
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if plain.A != nil && len(*plain.A) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "a", 1)
+	}
+	if plain.A != nil && len(*plain.A) > 4000 {
+		return fmt.Errorf("field %s length: must be <= %d", "a", 4000)
+	}
+	*j = SourceLocation(plain)
+	return nil
+}
+
+// Transformation operation for a ontology descriptor set.
+type Transform struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// From corresponds to the JSON schema field "from".
+	From TransformInput `json:"from" yaml:"from" mapstructure:"from"`
+
+	// Sources corresponds to the JSON schema field "sources".
+	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+
+	// The output for the transformation.
+	To []TransformOutput `json:"to" yaml:"to" mapstructure:"to"`
+}
+
+// The input ontology matcher.
+type TransformInput struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// Name of the descriptor to match against.  Does not apply to the 'and', 'or',
+	// and 'not' match types.
+	Descriptor *string `json:"descriptor,omitempty" yaml:"descriptor,omitempty" mapstructure:"descriptor,omitempty"`
+
+	// Regular expression pattern for matching the descriptor name.  Follows the same
+	// general rules as 'descriptor'.
+	DescriptorPattern *string `json:"descriptorPattern,omitempty" yaml:"descriptorPattern,omitempty" mapstructure:"descriptorPattern,omitempty"`
+
+	// The kind of matching operation encapsulated by this item. 'and', 'or', and
+	// 'not' use the 'sub' property to define the sub-inputs.  'descriptor' matches if
+	// the item has at least one value for the 'descriptor' or 'descriptorPattern'.
+	// 'one-of', 'all-of', 'includes', and 'equals' use the 'descriptor' or
+	// 'descriptorPattern' to indicate the descriptor matched, and 'values' or
+	// 'valuePatterns' for the list of values to compare.  'one-of' matches if at
+	// least one descriptor value matches a listed value.  'all-of' matches if every
+	// descriptor value matches a listed value.  'includes' matches if every listed
+	// value matches a descriptor value.  'equals' matches if both 'all-of' and
+	// 'includes' match.
+	MatchType TransformInputMatchType `json:"matchType" yaml:"matchType" mapstructure:"matchType"`
+
+	// Sources corresponds to the JSON schema field "sources".
+	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+
+	// A collection of matchers following the rules of the matchType.  This applies
+	// only to matchType of 'and', 'or', and 'not'.  'not' values should contain
+	// exactly 1 entry.
+	Sub []*TransformInput `json:"sub,omitempty" yaml:"sub,omitempty" mapstructure:"sub,omitempty"`
+
+	// Value regular expressions that, if matched, trigger the output.
+	ValuePatterns []string `json:"valuePatterns,omitempty" yaml:"valuePatterns,omitempty" mapstructure:"valuePatterns,omitempty"`
+
+	// Values that, if matched, trigger the output.
+	Values []string `json:"values,omitempty" yaml:"values,omitempty" mapstructure:"values,omitempty"`
+}
+
+type TransformInputMatchType string
+
+const TransformInputMatchTypeAllOf TransformInputMatchType = "all-of"
+const TransformInputMatchTypeAnd TransformInputMatchType = "and"
+const TransformInputMatchTypeDescriptor TransformInputMatchType = "descriptor"
+const TransformInputMatchTypeEquals TransformInputMatchType = "equals"
+const TransformInputMatchTypeIncludes TransformInputMatchType = "includes"
+const TransformInputMatchTypeNot TransformInputMatchType = "not"
+const TransformInputMatchTypeOneOf TransformInputMatchType = "one-of"
+const TransformInputMatchTypeOr TransformInputMatchType = "or"
+
+var enumValues_TransformInputMatchType = []interface{}{
+	"and",
+	"or",
+	"not",
+	"descriptor",
+	"one-of",
+	"all-of",
+	"includes",
+	"equals",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TransformInputMatchType) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_TransformInputMatchType {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_TransformInputMatchType, v)
+	}
+	*j = TransformInputMatchType(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TransformInput) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["matchType"]; raw != nil && !ok {
+		return fmt.Errorf("field matchType in TransformInput: required")
+	}
+	type Plain TransformInput
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if plain.Descriptor != nil && len(*plain.Descriptor) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "descriptor", 1)
+	}
+	if plain.Descriptor != nil && len(*plain.Descriptor) > 4000 {
+		return fmt.Errorf("field %s length: must be <= %d", "descriptor", 4000)
+	}
+	if plain.DescriptorPattern != nil && len(*plain.DescriptorPattern) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "descriptorPattern", 1)
+	}
+	if plain.DescriptorPattern != nil && len(*plain.DescriptorPattern) > 4000 {
+		return fmt.Errorf("field %s length: must be <= %d", "descriptorPattern", 4000)
+	}
+	*j = TransformInput(plain)
+	return nil
+}
+
+// A single transformation output value.  The transformation applies the outputs,
+// in order, to each item matching the input.
+type TransformOutput struct {
+	// Comment corresponds to the JSON schema field "$comment".
+	Comment *Comment `json:"$comment,omitempty" yaml:"$comment,omitempty" mapstructure:"$comment,omitempty"`
+
+	// Comments corresponds to the JSON schema field "$comments".
+	Comments CommentList `json:"$comments,omitempty" yaml:"$comments,omitempty" mapstructure:"$comments,omitempty"`
+
+	// How the output changes the item's descriptor.  'add' appends the values to the
+	// descriptor.  'set' replaces the descriptor's values with the values.  'remove'
+	// removes the matching values from the descriptor, or, if there are no values,
+	// removes the descriptor.
+	Action TransformOutputAction `json:"action,omitempty" yaml:"action,omitempty" mapstructure:"action,omitempty"`
+
+	// Descriptor corresponds to the JSON schema field "descriptor".
+	Descriptor DescriptorKey `json:"descriptor" yaml:"descriptor" mapstructure:"descriptor"`
+
+	// Sources corresponds to the JSON schema field "sources".
+	Sources DocumentSources `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources,omitempty"`
+
+	// Descriptor values used by the action.
+	Values []TransformOutputValuesElem `json:"values,omitempty" yaml:"values,omitempty" mapstructure:"values,omitempty"`
+}
+
+type TransformOutputAction string
+
+const TransformOutputActionAdd TransformOutputAction = "add"
+const TransformOutputActionRemove TransformOutputAction = "remove"
+const TransformOutputActionSet TransformOutputAction = "set"
+
+var enumValues_TransformOutputAction = []interface{}{
+	"add",
+	"set",
+	"remove",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TransformOutputAction) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_TransformOutputAction {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_TransformOutputAction, v)
+	}
+	*j = TransformOutputAction(v)
+	return nil
+}
+
+type TransformOutputValuesElem interface{}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TransformOutput) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["descriptor"]; raw != nil && !ok {
+		return fmt.Errorf("field descriptor in TransformOutput: required")
+	}
+	type Plain TransformOutput
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["action"]; !ok || v == nil {
+		plain.Action = "add"
+	}
+	*j = TransformOutput(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Transform) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["from"]; raw != nil && !ok {
+		return fmt.Errorf("field from in Transform: required")
+	}
+	if _, ok := raw["to"]; raw != nil && !ok {
+		return fmt.Errorf("field to in Transform: required")
+	}
+	type Plain Transform
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = Transform(plain)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
@@ -12,12 +13,16 @@ func ValidateAllDataAsync(all *ingest.AllData, probs problem.Adder, ctx context.
 	if all == nil {
 		return
 	}
+	// All the validators share one pool.
+	ctx = pool.With(ctx, pool.From(ctx))
 	chO := ValidateOntologyAsync(all.OntDescriptors, probs, ctx)
 	chD := ValidateDocumentsAsync(all.Documents, all.OntDescriptors, probs, ctx)
 	chR := ValidateRuleSetAsync(all.RuleSets, all.OntDescriptors, probs, ctx)
+	chX := ValidateTestExecutionsAsync(all.TestExecutions, all.OntDescriptors, probs, ctx)
 
 	// Completion order doesn't matter.
 	<-chO
 	<-chD
 	<-chR
+	<-chX
 }
@@ -31,7 +31,7 @@ func ValidateDescriptor(
 		ValidateEnum(context, d, typed.Enum, sources.Join(src, typed.Enum.Sources...), probs)
 	}
 	if typed.Free != nil {
-		ValidateFree(context, d, typed.Free, sources.Join(src, typed.Free.Sources...), probs)
+		ValidateFree(context, d, typed.Free, ont.Formats(), sources.Join(src, typed.Free.Sources...), probs)
 	}
 	if typed.Numeric != nil {
 		ValidateNumeric(context, d, typed.Numeric, sources.Join(src, typed.Numeric.Sources...), probs)
@@ -77,6 +77,7 @@ func ValidateFree(
 	context string,
 	d *descriptor.Descriptor,
 	ont *sont.FreeDesc,
+	formats *sont.Formats,
 	src []sources.Source,
 	probs problem.Adder,
 ) {
@@ -109,6 +110,7 @@ func ValidateFree(
 				d.Key,
 				t,
 				&con,
+				formats,
 				src,
 				probs,
 			)
@@ -143,8 +145,9 @@ func ValidateNumeric(
 			probs.AddError(
 				src,
 				"%s: numeric %s value (%f) outside bounds [%f, %f]",
-				n,
+				d.Key,
 				context,
+				n,
 				ont.Minimum,
 				ont.Maximum,
 			)
@@ -222,6 +225,7 @@ func checkConstraint(
 	key string,
 	val string,
 	con *sont.ValueConstraint,
+	formats *sont.Formats,
 	src []sources.Source,
 	probs problem.Adder,
 ) {
@@ -230,12 +234,24 @@ func checkConstraint(
 	}
 	switch con.Type {
 	case ontology.ValueConstraintTypeFormat:
-		probs.AddWarning(
-			src,
-			"%s: value constraint type '%s' not supported.",
-			key,
-			ontology.ValueConstraintTypeFormat,
-		)
+		if con.Format == nil {
+			probs.AddError(
+				src,
+				"%s: invalid value constraint; no format for 'format' type",
+				key,
+			)
+			return
+		}
+		// The ontology validation reports an undefined format once, rather than for each value.
+		if matched, defined := formats.Match(*con.Format, val); defined && !matched {
+			probs.AddError(
+				src,
+				"%s: value (%s) does not match constraint format (%s)",
+				key,
+				val,
+				*con.Format,
+			)
+		}
 	case ontology.ValueConstraintTypePattern:
 		if con.Pattern == nil {
 			probs.AddError(
@@ -0,0 +1,161 @@
+// Under the Apache-2.0 License
+package validate
+
+import (
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// ValidateContradictions reports AND clauses in the matchers that can never all match.
+//
+// The top matchers, and the matchers in an 'and' collection, must all match.  This finds
+// the contradictions that do not depend on the object values:
+//   - count matchers on the same key whose bounds have no common count, and
+//   - a contains matcher both required and negated by a 'not' collection.
+//
+// Each alternative of an 'or' collection is checked on its own.
+func ValidateContradictions(
+	mat *srule.MatchingDescriptorSet,
+	src []sources.Source,
+	probs problem.Adder,
+) {
+	if mat == nil {
+		return
+	}
+	var required, negated []*srule.ContainsMatcher
+	var alternatives []*srule.MatchingDescriptorSet
+	collectConjunction(mat, &required, &negated, &alternatives)
+
+	checkCountContradictions(required, src, probs)
+	for _, n := range negated {
+		for _, r := range required {
+			if sameContains(n, r) {
+				probs.AddError(
+					src,
+					"%s: contains matcher is both required and negated by a 'not' matcher, so the matchers can never match",
+					r.Key,
+				)
+				break
+			}
+		}
+	}
+
+	for _, alt := range alternatives {
+		validateAlternatives(alt, src, probs)
+	}
+}
+
+// collectConjunction gathers the matchers that must all match, joining in the 'and' collections.
+func collectConjunction(
+	mat *srule.MatchingDescriptorSet,
+	required *[]*srule.ContainsMatcher,
+	negated *[]*srule.ContainsMatcher,
+	alternatives *[]*srule.MatchingDescriptorSet,
+) {
+	if mat == nil {
+		return
+	}
+	for i := range mat.Contains {
+		*required = append(*required, &mat.Contains[i])
+	}
+	for _, c := range mat.Collection {
+		if c.Matchers == nil {
+			continue
+		}
+		switch c.Operation {
+		case srule.AndCollection:
+			collectConjunction(c.Matchers, required, negated, alternatives)
+		case srule.OrCollection:
+			*alternatives = append(*alternatives, c.Matchers)
+		case srule.NotCollection:
+			// Only a single negated contains matcher has a simple opposite.
+			if len(c.Matchers.Contains) == 1 && len(c.Matchers.Collection) == 0 && len(c.Matchers.Unique) == 0 {
+				*negated = append(*negated, &c.Matchers.Contains[0])
+			}
+		}
+	}
+}
+
+// validateAlternatives checks each 'and' collection of an 'or' collection's matchers.
+func validateAlternatives(
+	mat *srule.MatchingDescriptorSet,
+	src []sources.Source,
+	probs problem.Adder,
+) {
+	for _, c := range mat.Collection {
+		if c.Matchers == nil {
+			continue
+		}
+		switch c.Operation {
+		case srule.AndCollection:
+			ValidateContradictions(c.Matchers, src, probs)
+		case srule.OrCollection:
+			validateAlternatives(c.Matchers, src, probs)
+		}
+	}
+}
+
+// countKey identifies the count an object has for a count matcher.
+type countKey struct {
+	key      string
+	members  srule.MemberSelection
+	distinct bool
+}
+
+// checkCountContradictions reports count matchers for the same count with no common bounds.
+//
+// An object has a single count, so for a matcher with one bounds check, every contains
+// operation matches the same counts.
+func checkCountContradictions(
+	required []*srule.ContainsMatcher,
+	src []sources.Source,
+	probs problem.Adder,
+) {
+	bounds := make(map[countKey]srule.NumericBoundsCheck)
+	for _, r := range required {
+		if !r.Count || len(r.Checks.Numeric) != 1 || len(r.Checks.Text) != 0 {
+			continue
+		}
+		k := countKey{key: r.Key, members: r.Members, distinct: r.Distinct}
+		c := r.Checks.Numeric[0]
+		prev, ok := bounds[k]
+		if !ok {
+			bounds[k] = c
+			continue
+		}
+		joined := srule.NumericBoundsCheck{Min: max(prev.Min, c.Min), Max: min(prev.Max, c.Max)}
+		if joined.Min > joined.Max {
+			probs.AddError(
+				src,
+				"%s: count matchers require a count within both [%g, %g] and [%g, %g], so the matchers can never match",
+				r.Key,
+				prev.Min,
+				prev.Max,
+				c.Min,
+				c.Max,
+			)
+			continue
+		}
+		bounds[k] = joined
+	}
+}
+
+func sameContains(a *srule.ContainsMatcher, b *srule.ContainsMatcher) bool {
+	if a.Operation != b.Operation || a.Count != b.Count || a.Distinct != b.Distinct ||
+		a.Members != b.Members || a.Key != b.Key ||
+		len(a.Checks.Text) != len(b.Checks.Text) || len(a.Checks.Numeric) != len(b.Checks.Numeric) {
+		return false
+	}
+	for i, t := range a.Checks.Text {
+		if t.R.String() != b.Checks.Text[i].R.String() {
+			return false
+		}
+	}
+	for i, n := range a.Checks.Numeric {
+		if n != b.Checks.Numeric[i] {
+			return false
+		}
+	}
+	return true
+}
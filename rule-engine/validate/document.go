@@ -7,6 +7,7 @@ import (
 
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sdoc"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
@@ -26,6 +27,7 @@ func ValidateDocumentsAsync(
 		}()
 
 		var wg sync.WaitGroup
+		workers := pool.From(ctx)
 
 		if doc != nil {
 			for _, d := range doc.Objects {
@@ -33,15 +35,15 @@ func ValidateDocumentsAsync(
 					break
 				}
 				if d != nil {
+					dProbs := problem.WithOrigin(probs, d.Origin)
 					for _, desc := range d.Descriptors {
 						if ctx.Err() != nil {
 							break
 						}
-						wg.Add(1)
-						go func() {
-							defer onDefer("document descriptor", &wg, probs)
-							ValidateDescriptor("descriptor", desc, ont, d.Sources, probs)
-						}()
+						workers.Go(&wg, func() {
+							defer onDefer("document descriptor", nil, dProbs)
+							ValidateDescriptor("descriptor", desc, ont, d.Sources, dProbs)
+						})
 					}
 				}
 			}
@@ -0,0 +1,47 @@
+// Under the Apache-2.0 License
+package validate
+
+import (
+	"strings"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// ValidateGroupGraph reports the groups whose alterations feed back into their own matchers.
+//
+// The engine evaluates each group once, after the groups it depends on, so a
+// cycle has no valid evaluation order.
+func ValidateGroupGraph(
+	ruleSet *srule.RuleSet,
+	probs problem.Adder,
+) {
+	if ruleSet == nil {
+		return
+	}
+	graph := srule.NewGroupGraph(ruleSet.Groups)
+	for _, cycle := range graph.Cycles() {
+		ids := make([]string, len(cycle))
+		src := make([]sources.Source, 0)
+		for i, g := range cycle {
+			ids[i] = g.Id
+			if i > 0 {
+				src = append(src, g.Sources...)
+			}
+		}
+		if len(cycle) <= 2 {
+			probs.AddError(
+				src,
+				"group %s: matchers match the values set by its own alterations",
+				ids[0],
+			)
+			continue
+		}
+		probs.AddError(
+			src,
+			"groups form a dependency cycle, where each group's alterations set values the next group's matchers match: %s",
+			strings.Join(ids, " -> "),
+		)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/descriptor"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
@@ -26,22 +27,29 @@ func ValidateGroupAsync(
 		}()
 
 		var wg sync.WaitGroup
+		workers := pool.From(ctx)
 
 		if group != nil {
-			ValidateMatchersAsync(group.Matchers, ont, &wg, group.Sources, probs)
+			gProbs := problem.WithOrigin(probs, group.Origin)
+			ValidateMatchersAsync(group.Matchers, ont, &wg, workers, group.Sources, gProbs)
+			for _, k := range group.KeySharedValues {
+				checkKey("shared value", k, ont, group.Sources, gProbs)
+			}
 			for _, a := range group.Alterations {
-				wg.Add(1)
-				go func() {
-					defer onDefer("group alteration", &wg, probs)
-					ValidateAlteration(&a, ont, probs)
-				}()
+				workers.Go(&wg, func() {
+					defer onDefer("group alteration", nil, gProbs)
+					ValidateAlteration(&a, ont, gProbs)
+				})
 			}
 			for _, c := range group.Convergences {
-				wg.Add(1)
-				go func() {
-					defer onDefer("group convergence", &wg, probs)
-					ValidateConvergence(&c, ont, probs)
-				}()
+				workers.Go(&wg, func() {
+					defer onDefer("group convergence", nil, gProbs)
+					ValidateConvergence(&c, ont, gProbs)
+				})
+				ValidateMatchersAsync(c.Reference, ont, &wg, workers, c.Sources, problem.WithOrigin(gProbs, c.Origin))
+			}
+			for _, c := range group.Conformities {
+				ValidateConformityAsync(&c, ont, &wg, workers, gProbs)
 			}
 		}
 
@@ -59,6 +67,32 @@ func ValidateAlteration(
 	if alt == nil || ont == nil {
 		return
 	}
+	probs = problem.WithOrigin(probs, alt.Origin)
+	if alt.Action == srule.RemoveAction || alt.Action == srule.RemoveDistinctAction {
+		// Removing values can't exceed the maximum count, so check each value on its own.
+		if checkKey("alteration", alt.Key, ont, alt.Sources, probs) == nil {
+			return
+		}
+		for _, t := range alt.TextValues {
+			ValidateDescriptor(
+				"alteration",
+				&descriptor.Descriptor{Key: alt.Key, Text: []string{t}},
+				ont,
+				alt.Sources,
+				probs,
+			)
+		}
+		for _, n := range alt.NumberValues {
+			ValidateDescriptor(
+				"alteration",
+				&descriptor.Descriptor{Key: alt.Key, Number: []float64{n}},
+				ont,
+				alt.Sources,
+				probs,
+			)
+		}
+		return
+	}
 	ValidateDescriptor(
 		"alteration",
 		&descriptor.Descriptor{
@@ -80,7 +114,29 @@ func ValidateConvergence(
 	if con == nil || ont == nil {
 		return
 	}
+	probs = problem.WithOrigin(probs, con.Origin)
 	// Value type of the key not specified, and not needed.
 	// However, this can check for the existence of the key.
-	checkKey("convergence", con.Key, ont, con.Sources, probs)
+	typed := checkKey("convergence", con.Key, ont, con.Sources, probs)
+	if typed == nil {
+		return
+	}
+	if con.Requires == srule.WithinTolerance && typed.Numeric == nil {
+		probs.AddError(
+			con.Sources,
+			"%s: tolerance convergence requires a numeric key",
+			con.Key,
+		)
+	}
+	if (con.Requires == srule.SubsetOf || con.Requires == srule.SupersetOf) && isEmptyMatcher(con.Reference) {
+		probs.AddError(
+			con.Sources,
+			"%s: subset and superset convergences require reference matchers",
+			con.Key,
+		)
+	}
+}
+
+func isEmptyMatcher(m *srule.MatchingDescriptorSet) bool {
+	return m == nil || len(m.Collection)+len(m.Contains)+len(m.Unique) <= 0
 }
@@ -3,10 +3,15 @@ package validate
 
 import (
 	"context"
+	"regexp/syntax"
+	"strings"
 	"sync"
 
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/ontology"
 )
 
 // ValidateOntologyAsync checks the ontology against known restrictions.
@@ -28,27 +33,25 @@ func ValidateOntologyAsync(
 		}
 
 		var wg sync.WaitGroup
+		workers := pool.From(ctx)
 
 		for _, d := range ont.Enums() {
-			wg.Add(1)
-			go func() {
-				defer onDefer("ontology enum", &wg, probs)
+			workers.Go(&wg, func() {
+				defer onDefer("ontology enum", nil, probs)
 				ValidateOntEnum(d, probs)
-			}()
+			})
 		}
 		for _, d := range ont.Frees() {
-			wg.Add(1)
-			go func() {
-				defer onDefer("ontology free", &wg, probs)
-				ValidateOntFree(d, probs)
-			}()
+			workers.Go(&wg, func() {
+				defer onDefer("ontology free", nil, probs)
+				ValidateOntFree(d, ont.Formats(), probs)
+			})
 		}
 		for _, d := range ont.Numerics() {
-			wg.Add(1)
-			go func() {
-				defer onDefer("ontology numeric", &wg, probs)
+			workers.Go(&wg, func() {
+				defer onDefer("ontology numeric", nil, probs)
 				ValidateOntNumeric(d, probs)
-			}()
+			})
 		}
 
 		wg.Wait()
@@ -58,17 +61,100 @@ func ValidateOntologyAsync(
 }
 
 func ValidateOntEnum(d *sont.EnumDesc, probs problem.Adder) {
-	// Nothing really to do here.
+	if d == nil {
+		return
+	}
+	probs = problem.WithOrigin(probs, d.Origin)
+	checkOntDescription(d.Key, d.Comments, d.Sources, probs)
+	checkOntMaximum(d.Key, "count", d.MaximumCount, d.Sources, probs)
+	if len(d.Values) == 0 {
+		probs.AddError(
+			d.Sources,
+			"%s: enum descriptor has no values",
+			d.Key,
+		)
+		return
+	}
+
+	seen := make(map[string]bool)
+	folded := make(map[string]string)
+	for _, v := range d.Values {
+		if seen[v] {
+			probs.AddWarning(
+				d.Sources,
+				"%s: enum descriptor lists value '%s' more than once",
+				d.Key,
+				v,
+			)
+			continue
+		}
+		seen[v] = true
+
+		// Enum values match exactly, so values that differ only by case are
+		// distinct values that people will confuse.
+		f := strings.ToLower(v)
+		if prev, ok := folded[f]; ok {
+			probs.AddWarning(
+				d.Sources,
+				"%s: enum values '%s' and '%s' differ only by case",
+				d.Key,
+				prev,
+				v,
+			)
+			continue
+		}
+		folded[f] = v
+	}
 }
 
-func ValidateOntFree(d *sont.FreeDesc, probs problem.Adder) {
-	// Nothing really to do here.
+// ValidateOntFree checks the free descriptor, including that its constraint formats are defined.
+func ValidateOntFree(d *sont.FreeDesc, formats *sont.Formats, probs problem.Adder) {
+	if d == nil {
+		return
+	}
+	probs = problem.WithOrigin(probs, d.Origin)
+	checkOntDescription(d.Key, d.Comments, d.Sources, probs)
+	checkOntMaximum(d.Key, "count", d.MaximumCount, d.Sources, probs)
+	checkOntMaximum(d.Key, "length", d.MaximumLength, d.Sources, probs)
+	for _, con := range d.Constraints {
+		src := sources.Join(d.Sources, con.Sources...)
+		switch con.Type {
+		case ontology.ValueConstraintTypeFormat:
+			if con.Format == nil || *con.Format == "" {
+				probs.AddError(
+					src,
+					"%s: value constraint type 'format' has no format",
+					d.Key,
+				)
+			} else if formats != nil && !formats.Defined(*con.Format) {
+				probs.AddWarning(
+					src,
+					"%s: value constraint format '%s' not defined",
+					d.Key,
+					*con.Format,
+				)
+			}
+		case ontology.ValueConstraintTypePattern:
+			if con.Pattern == nil {
+				probs.AddError(
+					src,
+					"%s: value constraint type 'pattern' has no pattern",
+					d.Key,
+				)
+				continue
+			}
+			checkOntPattern(d.Key, *con.Pattern, src, probs)
+		}
+	}
 }
 
 func ValidateOntNumeric(d *sont.NumericDesc, probs problem.Adder) {
 	if d == nil {
 		return
 	}
+	probs = problem.WithOrigin(probs, d.Origin)
+	checkOntDescription(d.Key, d.Comments, d.Sources, probs)
+	checkOntMaximum(d.Key, "count", d.MaximumCount, d.Sources, probs)
 	if d.Minimum > d.Maximum {
 		probs.AddError(
 			d.Sources,
@@ -87,3 +173,90 @@ func ValidateOntNumeric(d *sont.NumericDesc, probs problem.Adder) {
 		)
 	}
 }
+
+// checkOntDescription reports descriptors without a comment describing their meaning.
+func checkOntDescription(key string, comments []string, src []sources.Source, probs problem.Adder) {
+	for _, c := range comments {
+		if strings.TrimSpace(c) != "" {
+			return
+		}
+	}
+	probs.AddInfo(
+		src,
+		"%s: descriptor has no description; add a '$comment' explaining its meaning",
+		key,
+	)
+}
+
+// checkOntMaximum reports a maximum that allows no values at all.
+func checkOntMaximum(key string, name string, max int, src []sources.Source, probs problem.Adder) {
+	if max < 1 {
+		probs.AddError(
+			src,
+			"%s: descriptor has a maximum %s of %d, which allows no values",
+			key,
+			name,
+			max,
+		)
+	}
+}
+
+// checkOntPattern reports constraint patterns that do not compile, or that can run in
+// exponential time.
+//
+// Go's regular expressions always run in linear time, but other tools that read the
+// ontology may use backtracking regular expressions.
+func checkOntPattern(key string, pattern string, src []sources.Source, probs problem.Adder) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		probs.AddError(
+			src,
+			"%s: invalid constraint pattern (%s): %s",
+			key,
+			pattern,
+			err.Error(),
+		)
+		return
+	}
+	if hasNestedRepeat(re, false) {
+		probs.AddWarning(
+			src,
+			"%s: constraint pattern (%s) nests unbounded repetitions, which backtracking regular expression engines run in exponential time",
+			key,
+			pattern,
+		)
+	}
+}
+
+// hasNestedRepeat finds an unbounded repetition that directly repeats another one, such as "(a+)+" or "(a|b*)*".
+//
+// Repetitions separated by other expressions, such as "([a-z]+\\.)*", do not count.
+func hasNestedRepeat(re *syntax.Regexp, inRepeat bool) bool {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		if inRepeat {
+			return true
+		}
+		return hasNestedRepeat(re.Sub[0], true)
+	case syntax.OpRepeat:
+		unbounded := re.Max < 0 || re.Max > 1
+		if inRepeat && unbounded {
+			return true
+		}
+		return hasNestedRepeat(re.Sub[0], inRepeat || unbounded)
+	case syntax.OpCapture, syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if hasNestedRepeat(sub, inRepeat) {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, sub := range re.Sub {
+			if hasNestedRepeat(sub, false) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+// Under the Apache-2.0 License
+package validate_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/schema/ontology"
+	"github.com/groboclown/qazaar-testing/rule-engine/validate"
+)
+
+func Test_ValidateOnt(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		descriptor string
+		levels     []problem.ProblemLevel
+	}{
+		{"ok enum", `{"$comment": "c", "type": "enum", "key": "k", "enum": ["a", "b"]}`, nil},
+		{"ok free", `{"$comment": "c", "type": "free", "key": "k", "constraints": [
+			{"type": "pattern", "pattern": "^([a-z]+\\.)*[a-z]+$"}, {"type": "format", "format": "uri"}]}`, nil},
+		{"no description", `{"type": "enum", "key": "k", "enum": ["a"]}`, []problem.ProblemLevel{problem.Info}},
+		{"empty enum", `{"$comment": "c", "type": "enum", "key": "k", "enum": []}`, []problem.ProblemLevel{problem.Err}},
+		{"duplicate enum", `{"$comment": "c", "type": "enum", "key": "k", "enum": ["a", "b", "a"]}`, []problem.ProblemLevel{problem.Warn}},
+		{"enum case", `{"$comment": "c", "type": "enum", "key": "k", "enum": ["a", "A"]}`, []problem.ProblemLevel{problem.Warn}},
+		{"enum count 0", `{"$comment": "c", "type": "enum", "key": "k", "enum": ["a"], "maximumCount": 0}`, []problem.ProblemLevel{problem.Err}},
+		{"free count 0", `{"$comment": "c", "type": "free", "key": "k", "maximumCount": 0}`, []problem.ProblemLevel{problem.Err}},
+		{"free length 0", `{"$comment": "c", "type": "free", "key": "k", "maximumLength": 0}`, []problem.ProblemLevel{problem.Err}},
+		{"bad pattern", `{"$comment": "c", "type": "free", "key": "k", "constraints": [{"type": "pattern", "pattern": "(a"}]}`, []problem.ProblemLevel{problem.Err}},
+		{"nested pattern", `{"$comment": "c", "type": "free", "key": "k", "constraints": [{"type": "pattern", "pattern": "^(a+)+$"}]}`, []problem.ProblemLevel{problem.Warn}},
+		{"nested alternate pattern", `{"$comment": "c", "type": "free", "key": "k", "constraints": [{"type": "pattern", "pattern": "(a|b*){2,}"}]}`, []problem.ProblemLevel{problem.Warn}},
+		{"no format", `{"$comment": "c", "type": "free", "key": "k", "constraints": [{"type": "format"}]}`, []problem.ProblemLevel{problem.Err}},
+		{"undefined format", `{"$comment": "c", "type": "free", "key": "k", "constraints": [{"type": "format", "format": "nope"}]}`, []problem.ProblemLevel{problem.Warn}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ont ontology.OntologyV1SchemaJson
+			if err := json.Unmarshal([]byte(`{"$schema": "", "commonSourceRefs": [], "descriptors": [`+tc.descriptor+`]}`), &ont); err != nil {
+				t.Fatal(err)
+			}
+			s := sont.New()
+			s.Add(&ont)
+			if s.Problems.HasProblems() {
+				t.Fatal(s.Problems.Problems())
+			}
+
+			ctx := context.Background()
+			pAdder, pReader := problem.Async(ctx)
+			for _, d := range s.Enums() {
+				validate.ValidateOntEnum(d, pAdder)
+			}
+			for _, d := range s.Frees() {
+				validate.ValidateOntFree(d, s.Formats(), pAdder)
+			}
+			pAdder.Complete()
+			found := pReader.Read(ctx).Problems()
+			if len(found) != len(tc.levels) {
+				t.Fatalf("expected %d problems, found %v", len(tc.levels), found)
+			}
+			for i, p := range found {
+				if p.Level != tc.levels[i] {
+					t.Errorf("expected level %d for '%s', found %d", tc.levels[i], p.Message, p.Level)
+				}
+			}
+		})
+	}
+}
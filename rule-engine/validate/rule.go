@@ -7,6 +7,7 @@ import (
 
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
@@ -25,11 +26,16 @@ func ValidateRuleAsync(
 		}()
 
 		var wg sync.WaitGroup
+		workers := pool.From(ctx)
 
 		if rule != nil {
-			ValidateMatchersAsync(rule.Matchers, ont, &wg, rule.Sources, probs)
+			rProbs := problem.WithOrigin(probs, rule.Origin)
+			ValidateMatchersAsync(rule.Matchers, ont, &wg, workers, rule.Sources, rProbs)
 			for _, c := range rule.Conformities {
-				ValidateConformityAsync(&c, ont, &wg, probs)
+				ValidateConformityAsync(&c, ont, &wg, workers, rProbs)
+			}
+			for _, c := range rule.Coverages {
+				ValidateCoverageAsync(&c, ont, &wg, workers, rProbs)
 			}
 		}
 
@@ -43,10 +49,30 @@ func ValidateConformityAsync(
 	mat *srule.LeveledMatcher,
 	ont *sont.AllowedDescriptors,
 	wg *sync.WaitGroup,
+	workers *pool.Pool,
 	probs problem.Adder,
 ) {
 	// TODO also need to check the level to see if the config has a reference to it.
 	if mat != nil {
-		ValidateMatchersAsync(mat.Matchers, ont, wg, mat.Sources, probs)
+		ValidateMatchersAsync(mat.Matchers, ont, wg, workers, mat.Sources, probs)
+	}
+}
+
+// ValidateCoverageAsync checks the coverage key and the counterpart matchers against the ontology.
+//
+// A typo in either means no counterpart ever covers a value.
+func ValidateCoverageAsync(
+	cov *srule.Coverage,
+	ont *sont.AllowedDescriptors,
+	wg *sync.WaitGroup,
+	workers *pool.Pool,
+	probs problem.Adder,
+) {
+	// TODO also need to check the level to see if the config has a reference to it.
+	if cov == nil || ont == nil {
+		return
 	}
+	probs = problem.WithOrigin(probs, cov.Origin)
+	checkKey("coverage", cov.Key, ont, cov.Sources, probs)
+	ValidateMatchersAsync(cov.Counterpart, ont, wg, workers, cov.Sources, probs)
 }
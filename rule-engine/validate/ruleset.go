@@ -8,6 +8,7 @@ import (
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/shared/sources"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
 	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
 	"github.com/groboclown/qazaar-testing/rule-engine/problem"
 )
 
@@ -26,34 +27,54 @@ func ValidateRuleSetAsync(
 		}()
 
 		var wg sync.WaitGroup
+		workers := pool.From(ctx)
 
 		for _, r := range ruleSet.Rules {
-			ch := ValidateRuleAsync(r, ont, probs, ctx)
-			wg.Add(1)
-			go func() {
-				defer onDefer("rule", &wg, probs)
-				<-ch
-			}()
+			workers.Go(&wg, func() {
+				defer onDefer("rule", nil, probs)
+				<-ValidateRuleAsync(r, ont, probs, ctx)
+			})
 		}
 		for _, g := range ruleSet.Groups {
-			ch := ValidateGroupAsync(g, ont, probs, ctx)
-			wg.Add(1)
-			go func() {
-				defer onDefer("group", &wg, probs)
-				<-ch
-			}()
+			workers.Go(&wg, func() {
+				defer onDefer("group", nil, probs)
+				<-ValidateGroupAsync(g, ont, probs, ctx)
+			})
 		}
 
+		ValidateGroupGraph(ruleSet, probs)
+
 		wg.Wait()
 	}()
 
 	return ret
 }
 
+// ValidateMatchersAsync checks the matchers against the ontology, and checks that their AND clauses can match.
 func ValidateMatchersAsync(
 	mat *srule.MatchingDescriptorSet,
 	ont *sont.AllowedDescriptors,
 	wg *sync.WaitGroup,
+	workers *pool.Pool,
+	src []sources.Source,
+	probs problem.Adder,
+) {
+	if mat == nil || ont == nil {
+		return
+	}
+	workers.Go(wg, func() {
+		defer onDefer("matcher contradictions", nil, probs)
+		ValidateContradictions(mat, src, probs)
+	})
+	validateMatchersAsync(mat, ont, wg, workers, src, probs)
+}
+
+// validateMatchersAsync checks each matcher, without the contradictions already found for the top matchers.
+func validateMatchersAsync(
+	mat *srule.MatchingDescriptorSet,
+	ont *sont.AllowedDescriptors,
+	wg *sync.WaitGroup,
+	workers *pool.Pool,
 	src []sources.Source,
 	probs problem.Adder,
 ) {
@@ -61,18 +82,19 @@ func ValidateMatchersAsync(
 		return
 	}
 	for _, m := range mat.Collection {
-		wg.Add(1)
-		go func() {
-			defer onDefer("collection matcher", wg, probs)
-			ValidateCollectionMatcherAsync(&m, ont, wg, src, probs)
-		}()
+		workers.Go(wg, func() {
+			defer onDefer("collection matcher", nil, probs)
+			ValidateCollectionMatcherAsync(&m, ont, wg, workers, src, probs)
+		})
 	}
 	for _, m := range mat.Contains {
-		wg.Add(1)
-		go func() {
-			defer onDefer("contains matcher", wg, probs)
+		workers.Go(wg, func() {
+			defer onDefer("contains matcher", nil, probs)
 			ValidateContainsMatcher(&m, ont, src, probs)
-		}()
+		})
+	}
+	for _, m := range mat.Unique {
+		checkKey("unique", m.Key, ont, src, problem.WithOrigin(probs, m.Origin))
 	}
 }
 
@@ -80,13 +102,14 @@ func ValidateCollectionMatcherAsync(
 	col *srule.CollectionMatcher,
 	ont *sont.AllowedDescriptors,
 	wg *sync.WaitGroup,
+	workers *pool.Pool,
 	src []sources.Source,
 	probs problem.Adder,
 ) {
 	if col == nil {
 		return
 	}
-	ValidateMatchersAsync(col.Matchers, ont, wg, src, probs)
+	validateMatchersAsync(col.Matchers, ont, wg, workers, src, problem.WithOrigin(probs, col.Origin))
 }
 
 func ValidateContainsMatcher(
@@ -98,6 +121,7 @@ func ValidateContainsMatcher(
 	if con == nil {
 		return
 	}
+	probs = problem.WithOrigin(probs, con.Origin)
 	typed := checkKey("contains", con.Key, ont, src, probs)
 	if typed == nil {
 		return
@@ -121,9 +145,18 @@ func ValidateContainsMatcher(
 					con.Key,
 				)
 			}
-			// Should only be exact equality checks.
-			// Should only have values in the enum.
-			// However, this requires additional, captured information.
+			// Both the equal and pattern checks are regular expressions, so a check that
+			// matches no enum value can never match; usually a typo.
+			for _, c := range con.Checks.Text {
+				if !matchesAnyEnum(c, typed.Enum) {
+					probs.AddError(
+						sources.Join(src, typed.Enum.Sources...),
+						"%s: enum-based contains matcher value ('%s') matches no enum value",
+						con.Key,
+						c.R.String(),
+					)
+				}
+			}
 		}
 	}
 	if typed.Free != nil {
@@ -147,6 +180,9 @@ func ValidateContainsMatcher(
 			}
 		}
 	}
+	if con.Count && con.Members != srule.UnionMembers {
+		checkCountBounds(con, typed, src, probs)
+	}
 	if typed.Numeric != nil {
 		if len(con.Checks.Text) != 0 {
 			probs.AddError(
@@ -168,3 +204,48 @@ func ValidateContainsMatcher(
 		}
 	}
 }
+
+func matchesAnyEnum(c srule.StringCheck, d *sont.EnumDesc) bool {
+	for v := range d.Enum {
+		if c.Matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCountBounds reports count matchers that require more values than the descriptor allows.
+//
+// The values of a SOG object come from all its members, so these can still match a SOG
+// object; that makes this a warning rather than an error.  The union of the members'
+// values has no such limit, so those matchers are not checked.
+func checkCountBounds(
+	con *srule.ContainsMatcher,
+	typed *sont.TypedDescriptor,
+	src []sources.Source,
+	probs problem.Adder,
+) {
+	maxCount := 0
+	var ontSrc []sources.Source
+	switch {
+	case typed.Enum != nil:
+		maxCount, ontSrc = typed.Enum.MaximumCount, typed.Enum.Sources
+	case typed.Free != nil:
+		maxCount, ontSrc = typed.Free.MaximumCount, typed.Free.Sources
+	case typed.Numeric != nil:
+		maxCount, ontSrc = typed.Numeric.MaximumCount, typed.Numeric.Sources
+	default:
+		return
+	}
+	for _, c := range con.Checks.Numeric {
+		if c.Min > float64(maxCount) {
+			probs.AddWarning(
+				sources.Join(src, ontSrc...),
+				"%s: count matcher minimum (%g) exceeds the descriptor maximum count (%d), so only SOG objects can match it",
+				con.Key,
+				c.Min,
+				maxCount,
+			)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Under the Apache-2.0 License
+package validate_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/srule"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/validate"
+)
+
+const ruleOnt = `{
+	"$schema": "",
+	"commonSourceRefs": [],
+	"descriptors": [
+		{"$comment": "c", "key": "kind", "type": "enum", "enum": ["field", "table"], "maximumCount": 1},
+		{"$comment": "c", "key": "name", "type": "free", "maximumCount": 1, "constraints": [{"type": "pattern", "pattern": "^[a-z]+$"}]},
+		{"$comment": "c", "key": "tag", "type": "free", "maximumCount": 3},
+		{"$comment": "c", "key": "size", "type": "number", "minimum": 0, "maximum": 10, "maximumCount": 1}
+	]
+}`
+
+func Test_ValidateRuleSet(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rules    string
+		expected []string
+	}{
+		{"ok", `"rules": [{"id": "r", "matchingDescriptors": [
+			{"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "field"}, {"type": "pattern", "text": "^t"}]},
+			{"key": "tag", "type": "containsSome", "count": true, "values": [{"type": "within", "minimum": 1, "maximum": 3}]},
+			{"type": "or", "collection": [
+				{"type": "and", "collection": [{"key": "size", "type": "containsSome", "values": [{"type": "within", "minimum": 0, "maximum": 1}]}]},
+				{"type": "and", "collection": [{"key": "size", "type": "containsSome", "values": [{"type": "within", "minimum": 2, "maximum": 3}]}]}
+			]}
+		]}]`, nil},
+		{"enum typo", `"rules": [{"id": "r", "matchingDescriptors": [
+			{"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "feild"}]}
+		]}]`, []string{"('feild') matches no enum value"}},
+		{"shared value key", `"groups": [{"id": "g", "sharedValues": ["nmae"], "matchingDescriptors": [
+			{"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]}
+		]}]`, []string{"undefined shared value key (nmae)"}},
+		{"alteration", `"groups": [{"id": "g", "sharedValues": ["name"], "matchingDescriptors": [
+			{"key": "size", "type": "containsSome", "values": [{"type": "within", "minimum": 0, "maximum": 1}]}
+		], "alterations": [
+			{"key": "kind", "action": "set", "values": ["tabel"]},
+			{"key": "name", "action": "add", "values": ["A", "b"]},
+			{"key": "kind", "action": "remove", "values": ["field", "table"]}
+		]}]`, []string{
+			"enum alteration invalid value (tabel)",
+			"does not match constraint pattern",
+			"alteration can have a maximum of 1 values (found 2)",
+		}},
+		{"count bounds", `"rules": [{"id": "r", "matchingDescriptors": [
+			{"key": "tag", "type": "containsSome", "count": true, "values": [{"type": "within", "minimum": 4, "maximum": 10}]}
+		]}]`, []string{"count matcher minimum (4) exceeds the descriptor maximum count (3)"}},
+		{"count contradiction", `"rules": [{"id": "r", "matchingDescriptors": [
+			{"key": "tag", "type": "containsSome", "count": true, "values": [{"type": "within", "minimum": 0, "maximum": 1}]},
+			{"type": "and", "collection": [
+				{"key": "tag", "type": "containsSome", "count": true, "values": [{"type": "within", "minimum": 2, "maximum": 3}]}
+			]}
+		]}]`, []string{"count within both [0, 1] and [2, 3]"}},
+		{"coverage", `"rules": [{"id": "r", "matchingDescriptors": [
+			{"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]}
+		], "coverages": [
+			{"key": "tga", "level": "error", "counterpart": [
+				{"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "tabel"}]}
+			]},
+			{"key": "tag", "level": "error", "counterpart": [
+				{"key": "nmae", "type": "containsSome", "values": [{"type": "equal", "text": "a"}]}
+			]}
+		]}]`, []string{
+			"undefined coverage key (tga)",
+			"('tabel') matches no enum value",
+			"undefined contains key (nmae)",
+		}},
+		{"negated contradiction", `"rules": [{"id": "r", "matchingDescriptors": [
+			{"type": "or", "collection": [
+				{"type": "and", "collection": [
+					{"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]},
+					{"type": "not", "matcher": {"key": "kind", "type": "containsSome", "values": [{"type": "equal", "text": "field"}]}}
+				]}
+			]}
+		]}]`, []string{"both required and negated"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ontSrc, err := ingest.ParseOntology(strings.NewReader(ruleOnt), "ont")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ruleSrc, err := ingest.ParseRule(strings.NewReader(`{"$schema": "", "commonSourceRefs": [], `+tc.rules+`}`), "rules")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ont := sont.New()
+			ont.Add(ontSrc)
+			rs := srule.New()
+			rs.Add(ruleSrc)
+			if rs.Problems.HasProblems() {
+				t.Fatal(rs.Problems.Problems())
+			}
+
+			ctx := context.Background()
+			pAdder, pReader := problem.Async(ctx)
+			<-validate.ValidateRuleSetAsync(rs, ont, pAdder, ctx)
+			pAdder.Complete()
+			found := pReader.Read(ctx).Problems()
+			if len(found) != len(tc.expected) {
+				t.Fatalf("expected %d problems, found %v", len(tc.expected), found)
+			}
+			for _, e := range tc.expected {
+				ok := false
+				for _, p := range found {
+					ok = ok || strings.Contains(p.Message, e)
+				}
+				if !ok {
+					t.Errorf("expected a problem with '%s', found %v", e, found)
+				}
+			}
+		})
+	}
+}
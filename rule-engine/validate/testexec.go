@@ -0,0 +1,60 @@
+// Under the Apache-2.0 License
+package validate
+
+import (
+	"context"
+	"sync"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/stexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/pool"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+)
+
+// ValidateTestExecutionsAsync validates the test executions' own descriptors, and returns a channel that reads once (and then closes) when they complete.
+//
+// The engine drops the descriptors that the ontology doesn't define, just like
+// for the documents.  The synthetic test descriptors come from the engine's
+// own ontology, so they aren't checked.
+func ValidateTestExecutionsAsync(
+	execs *stexec.Executions,
+	ont *sont.AllowedDescriptors,
+	probs problem.Adder,
+	ctx context.Context,
+) <-chan bool {
+	ret := make(chan bool)
+
+	go func() {
+		defer func() {
+			ret <- onDefer("test executions", nil, probs)
+			close(ret)
+		}()
+
+		var wg sync.WaitGroup
+		workers := pool.From(ctx)
+
+		if execs != nil {
+			for _, x := range execs.Executions {
+				if ctx.Err() != nil {
+					break
+				}
+				if x != nil {
+					xProbs := problem.WithOrigin(probs, x.Origin)
+					for _, desc := range x.Descriptors {
+						if ctx.Err() != nil {
+							break
+						}
+						workers.Go(&wg, func() {
+							defer onDefer("test execution descriptor", nil, xProbs)
+							ValidateDescriptor("descriptor", desc, ont, x.Sources, xProbs)
+						})
+					}
+				}
+			}
+		}
+
+		wg.Wait()
+	}()
+
+	return ret
+}
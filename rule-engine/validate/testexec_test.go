@@ -0,0 +1,65 @@
+// Under the Apache-2.0 License
+package validate_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/sont"
+	"github.com/groboclown/qazaar-testing/rule-engine/ingest/stexec"
+	"github.com/groboclown/qazaar-testing/rule-engine/problem"
+	"github.com/groboclown/qazaar-testing/rule-engine/validate"
+)
+
+func Test_ValidateTestExecutions(t *testing.T) {
+	ontSrc, err := ingest.ParseOntology(strings.NewReader(ruleOnt), "ont")
+	if err != nil {
+		t.Fatal(err)
+	}
+	execSrc, err := ingest.ParseTestExecution(strings.NewReader(`{
+		"$schema": "",
+		"commonSourceRefs": [{"id": "tests", "rep": "git", "loc": "t.go"}],
+		"executions": [{
+			"id": "t1",
+			"sources": [{"ref": "tests"}],
+			"timestamp": "2024-05-01T10:15:00Z",
+			"result": "pass",
+			"descriptors": [
+				{"key": "kind", "values": ["tabel"]},
+				{"key": "tga", "values": ["x"]},
+				{"key": "tag", "values": ["x"]}
+			]
+		}]
+	}`), "exec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ont := sont.New()
+	ont.Add(ontSrc)
+	ont.Add(stexec.Ontology())
+	execs := stexec.New()
+	execs.Add(execSrc)
+	if execs.Problems.HasProblems() {
+		t.Fatal(execs.Problems.Problems())
+	}
+
+	ctx := context.Background()
+	pAdder, pReader := problem.Async(ctx)
+	<-validate.ValidateTestExecutionsAsync(execs, ont, pAdder, ctx)
+	pAdder.Complete()
+	found := pReader.Read(ctx).Problems()
+	if len(found) != 2 {
+		t.Fatalf("expected 2 problems, found %v", found)
+	}
+	for _, e := range []string{"(tabel)", "(tga)"} {
+		ok := false
+		for _, p := range found {
+			ok = ok || strings.Contains(p.Message, e)
+		}
+		if !ok {
+			t.Errorf("expected a problem with '%s', found %v", e, found)
+		}
+	}
+}